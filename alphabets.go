@@ -0,0 +1,49 @@
+package generateids
+
+// Alphabets commonly used as character lists, to be passed as []byte(AlphabetCrockfordBase32) etc.
+const (
+	// AlphabetNumeric consists of decimal digits.
+	AlphabetNumeric = "0123456789"
+	// AlphabetHex consists of lowercase hexadecimal digits.
+	AlphabetHex = "0123456789abcdef"
+	// AlphabetAlphabetic consists of uppercase ASCII letters.
+	AlphabetAlphabetic = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// AlphabetAlphanumericUpper consists of uppercase ASCII letters and digits.
+	AlphabetAlphanumericUpper = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// AlphabetAlphanumericLower consists of lowercase ASCII letters and digits.
+	AlphabetAlphanumericLower = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// AlphabetAlphanumericMixed consists of uppercase and lowercase ASCII letters and digits.
+	AlphabetAlphanumericMixed = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	// AlphabetCrockfordBase32 is the Crockford's Base32 alphabet, without letters I, L, O and U.
+	// Ids typed by people can be matched after NormalizeCrockford.
+	AlphabetCrockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// AlphabetBase32 is the base32 alphabet of RFC 4648.
+	AlphabetBase32 = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	// AlphabetBase64URL is the URL and filename safe base64 alphabet of RFC 4648.
+	AlphabetBase64URL = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	// AlphabetNoLookalikes consists of letters and digits without those easily confused with each other,
+	// such as 1, l and I, 0, O and o, 2 and Z, 5 and S, U, u, V and v.
+	AlphabetNoLookalikes = "346789ABCDEFGHJKLMNPQRTWXYabcdefghijkmnpqrtwxyz"
+)
+
+// NormalizeCrockford returns a copy of the id with the decoding rules of Crockford's Base32 applied:
+// lowercase letters are turned into uppercase, I and L into 1, and O into 0. Other characters are left unchanged.
+// Unlike in the Crockford's decoding, hyphens are not removed, as they may be literals of a template.
+func NormalizeCrockford(id []byte) []byte {
+	normalized := make([]byte, len(id))
+	for i, char := range id {
+		if 'a' <= char && char <= 'z' {
+			char -= 'a' - 'A'
+		}
+
+		switch char {
+		case 'I', 'L':
+			char = '1'
+		case 'O':
+			char = '0'
+		}
+		normalized[i] = char
+	}
+
+	return normalized
+}
@@ -0,0 +1,97 @@
+package generateids
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestAlphabets(t *testing.T) {
+	testCases := []struct {
+		alphabet    string
+		totalChars  int
+		lookalikes  string
+		description string
+	}{
+		{AlphabetNumeric, 10, "", "numeric"},
+		{AlphabetHex, 16, "", "hex"},
+		{AlphabetAlphabetic, 26, "", "alphabetic"},
+		{AlphabetAlphanumericUpper, 36, "", "alphanumeric upper"},
+		{AlphabetAlphanumericLower, 36, "", "alphanumeric lower"},
+		{AlphabetAlphanumericMixed, 62, "", "alphanumeric mixed"},
+		{AlphabetCrockfordBase32, 32, "ILOU", "Crockford base32"},
+		{AlphabetBase32, 32, "0189", "base32"},
+		{AlphabetBase64URL, 64, "", "base64url"},
+		{AlphabetNoLookalikes, 47, "01lIOo2Z5SsUuVv", "no lookalikes"},
+	}
+
+	for _, tc := range testCases {
+		if len(tc.alphabet) != tc.totalChars {
+			t.Errorf("expected %s alphabet to have %d chars, got %d", tc.description, tc.totalChars, len(tc.alphabet))
+		}
+
+		if _, err := New(WithCount(1), WithLength(1), WithCharList([]byte(tc.alphabet))); err != nil {
+			t.Errorf("expected %s alphabet to be valid, got %v", tc.description, err)
+		}
+
+		for _, char := range []byte(tc.lookalikes) {
+			if bytes.IndexByte([]byte(tc.alphabet), char) >= 0 {
+				t.Errorf("expected %s alphabet not to contain %s", tc.description, string(char))
+			}
+		}
+	}
+
+	if expected := "346789ABCDEFGHJKLMNPQRTWXYabcdefghijkmnpqrtwxyz"; AlphabetNoLookalikes != expected {
+		t.Errorf("expected no lookalikes alphabet %s, got %s", expected, AlphabetNoLookalikes)
+	}
+}
+
+func TestNormalizeCrockford(t *testing.T) {
+	t.Run("applies decoding rules", func(t *testing.T) {
+		testCases := []struct {
+			id       string
+			expected string
+		}{
+			{"", ""},
+			{"0123456789ABCDEFGHJKMNPQRSTVWXYZ", "0123456789ABCDEFGHJKMNPQRSTVWXYZ"},
+			{"abcxyz", "ABCXYZ"},
+			{"IiLlOo", "111100"},
+			{"INV-o1l-2025", "1NV-011-2025"},
+		}
+
+		for _, tc := range testCases {
+			id := []byte(tc.id)
+			normalized := NormalizeCrockford(id)
+			if string(normalized) != tc.expected {
+				t.Errorf("expected %q to be normalized to %q, got %q", tc.id, tc.expected, normalized)
+			}
+			if string(id) != tc.id {
+				t.Errorf("expected %q not to be modified, got %q", tc.id, id)
+			}
+		}
+	})
+
+	t.Run("matches mistyped generated ids", func(t *testing.T) {
+		generator, err := New(
+			WithCount(500), WithLength(6), WithCharList([]byte(AlphabetCrockfordBase32)), WithSeed(7),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, id := range ids {
+			typed := bytes.ToLower(id)
+			typed = bytes.ReplaceAll(typed, []byte{'1'}, []byte{'l'})
+			typed = bytes.ReplaceAll(typed, []byte{'0'}, []byte{'O'})
+
+			if normalized := NormalizeCrockford(typed); !bytes.Equal(normalized, id) {
+				t.Fatalf("expected %s to be normalized to %s, got %s", typed, id, normalized)
+			}
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package generateids
+
+import (
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// Blocklist describes ids which must never be returned by the Generator. Ids equal to one of the words
+// or containing one of the substrings are skipped and replaced by other ids from the set. ASCII letters
+// are matched case-insensitively, and the terms are matched against the ids exactly as they are returned,
+// including literals of the template and multi-byte symbols.
+type Blocklist struct {
+	Words      []string `json:"words,omitempty"`
+	Substrings []string `json:"substrings,omitempty"`
+}
+
+func (b Blocklist) empty() bool {
+	return len(b.Words) == 0 && len(b.Substrings) == 0
+}
+
+// setupFilters counts the allowed ids and extends the column schedule by the numbers of blocked and excluded ids,
+// so that at least idsToGenerate allowed ids are scheduled. If the extended schedule does not fit in int,
+// it is capped at math.MaxInt ids, which cannot be generated in practice anyway.
+func (g *Generator) setupFilters(b Blocklist, exclusion Exclusion) error {
+	segments := g.segments()
+	idsAllowed, idsBlocked := internal.Capacity(segments), 0
+	if !b.empty() {
+		g.blocklist = internal.NewBlocklist(b.Words, b.Substrings)
+		g.blockedTerms = b
+		idsAllowed, idsBlocked = g.countBlocked(segments)
+	}
+
+	idsExcluded := 0
+	if exclusion != nil {
+		g.exclusion = exclusion
+		idsExcluded = exclusion.Len()
+	}
+
+	err := internal.ValidateFilteredCapacity(g.idsScheduled, idsAllowed, idsExcluded)
+	if err != nil {
+		return err
+	}
+
+	g.scheduleSize = internal.FilteredScheduleSize(g.idsScheduled, idsAllowed, idsBlocked, idsExcluded)
+	return nil
+}
+
+func (g *Generator) filtered() bool {
+	return g.blocklist != nil || g.exclusion != nil
+}
+
+// countBlocked returns the numbers of allowed and blocked ids. Check characters are computed from the rest
+// of the id, so they are counted with the only suffix every id can have.
+func (g *Generator) countBlocked(segments [][][]byte) (int, int) {
+	if g.checksum == nil {
+		return g.blocklist.Count(segments, nil, nil)
+	}
+
+	return g.blocklist.Count(segments, g.checksum, g.symbolAlternatives(g.charList))
+}
+
+// segments returns the alternatives for every part of the returned ids: characters or symbols of the columns
+// and literals of the template.
+func (g *Generator) segments() [][][]byte {
+	alternatives := make([][][]byte, len(g.charLists))
+	for i, charList := range g.charLists {
+		alternatives[i] = g.symbolAlternatives(charList)
+	}
+
+	if g.template != nil {
+		return g.template.Segments(alternatives)
+	}
+
+	return alternatives
+}
+
+func (g *Generator) symbolAlternatives(charList []byte) [][]byte {
+	alternatives := make([][]byte, len(charList))
+	for i, char := range charList {
+		if g.symbols != nil {
+			alternatives[i] = g.symbols.Render([]byte{char})
+		} else {
+			alternatives[i] = []byte{char}
+		}
+	}
+
+	return alternatives
+}
+
+// filteredSchedule skips the ids which are blocked or excluded once encoded, so that positions in the schedule
+// are counted in allowed ids only. If an exclusion reports more ids than its length, the column schedule runs out
+// of ids before the allowed ones are generated, and the schedule stops with ErrExclusionExceeded.
+type filteredSchedule struct {
+	g        *Generator
+	schedule internal.Schedule
+	idsLeft  int
+	encoded  []byte
+	rendered []byte
+	err      error
+}
+
+func (fs *filteredSchedule) Next(id []byte) {
+	for fs.err == nil {
+		if fs.idsLeft == 0 {
+			fs.err = ErrExclusionExceeded
+			return
+		}
+
+		fs.schedule.Next(id)
+		fs.idsLeft--
+
+		copy(fs.encoded, id)
+		fs.rendered = fs.g.appendId(fs.rendered[:0], fs.encoded)
+		if !fs.g.skipped(fs.rendered) {
+			return
+		}
+	}
+}
+
+func (fs *filteredSchedule) Skip(idsToSkip int) {
+	id := make([]byte, len(fs.encoded))
+	for i := 0; i < idsToSkip && fs.err == nil; i++ {
+		fs.Next(id)
+	}
+}
+
+// scheduleErr returns the error of the schedule if it ran out of ids, which is possible only when filtered.
+func scheduleErr(schedule internal.Schedule) error {
+	if fs, ok := schedule.(*filteredSchedule); ok {
+		return fs.err
+	}
+	return nil
+}
+
+func (g *Generator) skipped(id []byte) bool {
+	if g.blocklist != nil && g.blocklist.Blocked(id) {
+		return true
+	}
+
+	return g.exclusion != nil && g.exclusion.Contains(id)
+}
@@ -0,0 +1,211 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_Blocklist(t *testing.T) {
+	t.Run("returns all allowed ids and no blocked ones", func(t *testing.T) {
+		blocklist := Blocklist{Words: []string{"babab"}, Substrings: []string{"aaa", "bBa"}}
+
+		idsAllowed := 0
+		for i := 0; i < 32; i++ {
+			id := make([]byte, 5)
+			for position := range id {
+				id[position] = charsAB[(i>>position)&1]
+			}
+			if !isBlocked(string(id), blocklist) {
+				idsAllowed++
+			}
+		}
+
+		for _, workers := range []int{0, 2} {
+			opts := []Option{WithCount(idsAllowed), WithLength(5), WithCharList(charsAB), WithBlocklist(blocklist)}
+			if workers > 0 {
+				opts = append(opts, WithWorkers(workers))
+			}
+
+			results := generateIdsWithOptions(t, opts...)
+			assertAllowedUniqueIds(t, results, idsAllowed, blocklist)
+
+			_, err := New(append(opts, WithCount(idsAllowed+1))...)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for %d ids, got %v", idsAllowed+1, err)
+			}
+		}
+	})
+
+	t.Run("returns exact number of ids from a large set", func(t *testing.T) {
+		blocklist := Blocklist{Substrings: []string{"ass", "FUK", "69"}}
+		results := generateIdsWithOptions(t,
+			WithCount(50000), WithLength(4), WithCharList(charsAlphanumeric), WithSeed(1), WithBlocklist(blocklist),
+		)
+		assertAllowedUniqueIds(t, results, 50000, blocklist)
+	})
+
+	t.Run("matches literals of the template", func(t *testing.T) {
+		blocklist := Blocklist{Substrings: []string{"-0"}, Words: []string{"x1-1"}}
+		results := generateIdsWithOptions(t,
+			WithCount(17), WithTemplate("[XY][0-9]-[0-9]"), WithSeed(2), WithBlocklist(blocklist),
+		)
+		assertAllowedUniqueIds(t, results, 17, blocklist)
+	})
+
+	t.Run("counts positions in allowed ids", func(t *testing.T) {
+		blocklist := Blocklist{Substrings: []string{"AB"}}
+		opts := []Option{WithCount(300), WithLength(7), WithCharList(charsABC), WithSeed(3), WithBlocklist(blocklist)}
+		expected := generateIdsWithOptions(t, opts...)
+
+		generator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for _, index := range []int{0, 150, 299} {
+			id, err := generator.At(context.Background(), index)
+			if err != nil || string(id) != string(expected[index]) {
+				t.Errorf("expected %s at %d, got %s, %v", expected[index], index, id, err)
+			}
+
+			foundIndex, err := generator.IndexOf(context.Background(), id)
+			if err != nil || foundIndex != index {
+				t.Errorf("expected index %d of %s, got %d, %v", index, id, foundIndex, err)
+			}
+		}
+
+		resumed, err := New(append(opts, withIdsGenerated(100))...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		checkpoint, err := resumed.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint method error: %s", err)
+		}
+
+		resumed, err = NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, expected[100:], results)
+
+		var shards [][]byte
+		for shardIndex := 0; shardIndex < 3; shardIndex++ {
+			shards = append(shards, generateIdsWithOptions(t, append(opts, WithShard(shardIndex, 3))...)...)
+		}
+		assertSameIds(t, generateIdsWithOptions(t, append(opts, WithWorkers(2))...), shards)
+	})
+
+	t.Run("counts ids blocked by their check characters exactly", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			check     CheckAlgorithm
+			opts      []Option
+			blocklist Blocklist
+		}{
+			{"luhn", CheckLuhn, []Option{WithLength(3), WithCharList(charsDigits)}, Blocklist{Substrings: []string{"9"}}},
+			{"damm", CheckDamm, []Option{WithLength(3), WithCharList(charsDigits)}, Blocklist{Substrings: []string{"07", "5"}}},
+			{"iso 7064 hybrid", CheckISO7064Hybrid, []Option{WithLength(2), WithCharList(charsDigitsLetters)}, Blocklist{Substrings: []string{"Z", "1a"}}},
+			{"iso 7064 pure", CheckISO7064Pure, []Option{WithLength(3), WithCharList(charsDigits)}, Blocklist{Substrings: []string{"00"}, Words: []string{"12345"}}},
+			{"luhn symbols", CheckLuhn, []Option{WithLength(3), WithSymbols(Runes("αβγδε"))}, Blocklist{Substrings: []string{"δα"}}},
+		}
+
+		for _, tc := range testCases {
+			generator, err := New(append(tc.opts, WithCount(1), WithCheckCharacters(tc.check), WithBlocklist(tc.blocklist))...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error for %s: %s", tc.name, err)
+			}
+
+			expectedAllowed, expectedBlocked := 0, 0
+			payloads := [][]byte{{}}
+			for i := 0; i < generator.idLength; i++ {
+				var longer [][]byte
+				for _, payload := range payloads {
+					for _, char := range generator.charList {
+						longer = append(longer, append(append([]byte(nil), payload...), char))
+					}
+				}
+				payloads = longer
+			}
+			for _, payload := range payloads {
+				if isBlocked(string(generator.render(generator.appendCheck(payload))), tc.blocklist) {
+					expectedBlocked++
+				} else {
+					expectedAllowed++
+				}
+			}
+
+			allowed, blocked := generator.countBlocked(generator.segments())
+			if allowed != expectedAllowed || blocked != expectedBlocked {
+				t.Errorf("expected %d allowed and %d blocked ids for %s, got %d and %d",
+					expectedAllowed, expectedBlocked, tc.name, allowed, blocked)
+			}
+
+			opts := append(tc.opts, WithCheckCharacters(tc.check), WithBlocklist(tc.blocklist))
+			assertAllowedUniqueIds(t, generateIdsWithOptions(t, append(opts, WithCount(allowed))...), allowed, tc.blocklist)
+		}
+	})
+
+	t.Run("returns error when blocked term is empty", func(t *testing.T) {
+		_, err := New(WithCount(1), WithLength(1), WithCharList(charsAB), WithBlocklist(Blocklist{Words: []string{""}}))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func isBlocked(id string, blocklist Blocklist) bool {
+	id = strings.ToLower(id)
+	for _, word := range blocklist.Words {
+		if id == strings.ToLower(word) {
+			return true
+		}
+	}
+
+	for _, substring := range blocklist.Substrings {
+		if strings.Contains(id, strings.ToLower(substring)) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateIdsWithOptions(t *testing.T, opts ...Option) [][]byte {
+	generator, err := New(opts...)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %s", err)
+	}
+
+	results, err := generator.Array(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected array method error: %s", err)
+	}
+
+	return results
+}
+
+func assertAllowedUniqueIds(t *testing.T, results [][]byte, idsToGenerate int, blocklist Blocklist) {
+	if len(results) != idsToGenerate {
+		t.Errorf("expected %d results, got %d", idsToGenerate, len(results))
+	}
+
+	uniqueIds := make(map[string]struct{}, len(results))
+	for _, id := range results {
+		if isBlocked(string(id), blocklist) {
+			t.Errorf("unexpected blocked id %s", id)
+		}
+		uniqueIds[string(id)] = struct{}{}
+	}
+
+	if len(uniqueIds) != len(results) {
+		t.Errorf("expected %d unique ids, got %d", len(results), len(uniqueIds))
+	}
+}
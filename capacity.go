@@ -0,0 +1,45 @@
+package generateids
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// MaxUnique returns the exact number of unique ids of the given length consisting of characters
+// from the character list. Unlike the constructor's validation, it is not capped at math.MaxInt.
+// Returns ErrValidation if the length is not positive or the character list is empty or has duplicates.
+func MaxUnique(idLength int, charList []byte) (*big.Int, error) {
+	if err := internal.Validate(1, idLength, charList); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	totalChars := big.NewInt(int64(len(charList)))
+	return totalChars.Exp(totalChars, big.NewInt(int64(idLength)), nil), nil
+}
+
+// MinLength returns the minimum length of ids allowing to generate the given number of unique ids
+// from the character list. Returns ErrValidation if the number is not positive or the character list
+// is invalid or consists of a single character while more than one id is requested.
+func MinLength(idsToGenerate int, charList []byte) (int, error) {
+	idLength, err := internal.MinLength(idsToGenerate, charList)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	return idLength, nil
+}
+
+// EntropyBits returns the number of bits needed to tell apart all ids of the given length consisting
+// of characters from the character list, i.e. the binary logarithm of MaxUnique. It describes the size
+// of the space of ids, not their unpredictability: generated ids are derived from the seed or random reader.
+// Returns ErrValidation if the length is not positive or the character list is empty or has duplicates.
+func EntropyBits(idLength int, charList []byte) (float64, error) {
+	if err := internal.Validate(1, idLength, charList); err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	return float64(idLength) * math.Log2(float64(len(charList))), nil
+}
@@ -0,0 +1,132 @@
+package generateids
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMaxUnique(t *testing.T) {
+	t.Run("returns exact number of unique ids", func(t *testing.T) {
+		testCases := []struct {
+			idLength int
+			charList []byte
+			expected string
+		}{
+			{1, charsAB, "2"},
+			{10, charsDigits, "10000000000"},
+			{1, charsAlphanumeric[:1], "1"},
+			{16, charsAlphanumeric, "7958661109946400884391936"},
+			{128, charsAB, "340282366920938463463374607431768211456"},
+		}
+
+		for _, tc := range testCases {
+			maxUnique, err := MaxUnique(tc.idLength, tc.charList)
+			if err != nil {
+				t.Fatalf("unexpected max unique error: %s", err)
+			}
+
+			expected, _ := new(big.Int).SetString(tc.expected, 10)
+			if maxUnique.Cmp(expected) != 0 {
+				t.Errorf("expected %s unique ids of length %d, got %s", expected, tc.idLength, maxUnique)
+			}
+		}
+	})
+
+	t.Run("returns validation error", func(t *testing.T) {
+		for _, charList := range [][]byte{nil, []byte("AA")} {
+			_, err := MaxUnique(1, charList)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for %q, got %v", charList, err)
+			}
+		}
+
+		_, err := MaxUnique(0, charsAB)
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error for zero length, got %v", err)
+		}
+	})
+}
+
+func TestMinLength(t *testing.T) {
+	t.Run("returns minimum length", func(t *testing.T) {
+		testCases := []struct {
+			idsToGenerate int
+			charList      []byte
+			expected      int
+		}{
+			{1, charsAlphanumeric[:1], 1},
+			{1, charsAB, 1},
+			{2, charsAB, 1},
+			{3, charsAB, 2},
+			{1000, charsDigits, 3},
+			{1001, charsDigits, 4},
+			{math.MaxInt, charsAB, 63},
+			{math.MaxInt, charsAlphanumeric, 13},
+		}
+
+		for _, tc := range testCases {
+			idLength, err := MinLength(tc.idsToGenerate, tc.charList)
+			if err != nil {
+				t.Fatalf("unexpected min length error: %s", err)
+			}
+
+			if idLength != tc.expected {
+				t.Errorf("expected length %d for %d ids of %q, got %d", tc.expected, tc.idsToGenerate, tc.charList, idLength)
+			}
+
+			if _, err = NewGenerator(tc.idsToGenerate, idLength, tc.charList); err != nil {
+				t.Errorf("expected %d ids of length %d to be valid, got %v", tc.idsToGenerate, idLength, err)
+			}
+		}
+	})
+
+	t.Run("returns validation error", func(t *testing.T) {
+		testCases := []struct {
+			idsToGenerate int
+			charList      []byte
+		}{
+			{0, charsAB},
+			{1, nil},
+			{1, []byte("AA")},
+			{2, charsAlphanumeric[:1]},
+		}
+
+		for _, tc := range testCases {
+			_, err := MinLength(tc.idsToGenerate, tc.charList)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for %d ids of %q, got %v", tc.idsToGenerate, tc.charList, err)
+			}
+		}
+	})
+}
+
+func TestEntropyBits(t *testing.T) {
+	testCases := []struct {
+		idLength int
+		charList []byte
+		expected float64
+	}{
+		{1, charsAlphanumeric[:1], 0},
+		{128, charsAB, 128},
+		{4, []byte(AlphabetHex), 16},
+		{26, []byte(AlphabetCrockfordBase32), 130},
+	}
+
+	for _, tc := range testCases {
+		bits, err := EntropyBits(tc.idLength, tc.charList)
+		if err != nil {
+			t.Fatalf("unexpected entropy bits error: %s", err)
+		}
+
+		if math.Abs(bits-tc.expected) > 1e-9 {
+			t.Errorf("expected %f bits for length %d, got %f", tc.expected, tc.idLength, bits)
+		}
+	}
+
+	_, err := EntropyBits(1, []byte("AA"))
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package generateids
+
+import (
+	"fmt"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// CheckAlgorithm computes check characters appended to the ids, so that mistyped ids can be detected
+// without looking them up. Check characters are computed over the indices of characters in the character list.
+type CheckAlgorithm int
+
+const (
+	// CheckNone appends no check characters.
+	CheckNone CheckAlgorithm = iota
+	// CheckLuhn appends one character of the Luhn mod N algorithm, detecting all single character errors
+	// and most transpositions of adjacent characters.
+	CheckLuhn
+	// CheckDamm appends one character of the Damm algorithm, detecting all single character errors
+	// and all transpositions of adjacent characters. It does not support 2, 6 or other numbers
+	// of characters divisible by 2 but not by 4, except 10.
+	CheckDamm
+	// CheckISO7064Hybrid appends one character of the ISO 7064 hybrid system MOD N+1,N, e.g. MOD 37,36
+	// for 36 characters.
+	CheckISO7064Hybrid
+	// CheckISO7064Pure appends two characters of the ISO 7064 pure system MOD 97-10, MOD 661-26 or MOD 1271-36,
+	// for 10, 26 or 36 characters respectively. It detects nearly all errors, including double substitutions.
+	CheckISO7064Pure
+)
+
+// Verify returns true if the id consists of characters from the character list and ends with the check characters
+// of the algorithm computed over the rest of the id. It does not require the Generator the id comes from.
+// Returns ErrValidation if the algorithm does not support the character list.
+func Verify(id, charList []byte, algorithm CheckAlgorithm) (bool, error) {
+	err := internal.ValidateCheck(int(algorithm), charList, false)
+	if err == nil && algorithm != CheckNone {
+		err = internal.Validate(1, 1, charList)
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w: %s", ErrValidation, err)
+	}
+
+	if algorithm == CheckNone {
+		return internal.ValidateID(id, internal.RepeatCharList(charList, len(id))) == nil, nil
+	}
+	return internal.NewChecksum(int(algorithm), charList).Valid(id), nil
+}
+
+// stripCheck returns the column form of the id without check characters, or an error if they do not match.
+func (g *Generator) stripCheck(columns []byte) ([]byte, error) {
+	if g.checksum == nil {
+		return columns, nil
+	}
+
+	return g.checksum.Strip(columns)
+}
+
+// appendCheck appends check characters to the encoded id in its column form.
+func (g *Generator) appendCheck(columns []byte) []byte {
+	if g.checksum == nil {
+		return columns
+	}
+
+	return g.checksum.Append(columns)
+}
+
+func (g *Generator) checkLength() int {
+	if g.checksum == nil {
+		return 0
+	}
+
+	return g.checksum.Length()
+}
@@ -0,0 +1,182 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+var (
+	charsDigits        = []byte("0123456789")
+	charsAlphabetic    = []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	charsDigitsLetters = []byte("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+)
+
+func TestVerify(t *testing.T) {
+	t.Run("accepts known check characters", func(t *testing.T) {
+		testCases := []struct {
+			id        string
+			charList  []byte
+			algorithm CheckAlgorithm
+		}{
+			{"79927398713", charsDigits, CheckLuhn},
+			{"5724", charsDigits, CheckDamm},
+			{"07945", charsDigits, CheckISO7064Hybrid},
+			{"A12425GABC1234002M", charsDigitsLetters, CheckISO7064Hybrid},
+			{"79444", charsDigits, CheckISO7064Pure},
+			{"ISO793W", charsDigitsLetters, CheckISO7064Pure},
+		}
+
+		for _, tc := range testCases {
+			valid, err := Verify([]byte(tc.id), tc.charList, tc.algorithm)
+			if err != nil || !valid {
+				t.Errorf("expected %s to be valid, got %t, %v", tc.id, valid, err)
+			}
+		}
+	})
+
+	t.Run("detects single substitutions and adjacent transpositions", func(t *testing.T) {
+		for _, charList := range [][]byte{charsDigits, charsDigitsLetters, []byte("ABCDEFGHIJK"), charsAlphanumeric[:32]} {
+			for _, algorithm := range []CheckAlgorithm{CheckDamm, CheckISO7064Pure} {
+				if algorithm == CheckISO7064Pure && len(charList) != 10 && len(charList) != 36 {
+					continue
+				}
+
+				generator, err := New(WithCount(50), WithLength(6), WithCharList(charList), WithCheckCharacters(algorithm))
+				if err != nil {
+					t.Fatalf("unexpected constructor error: %s", err)
+				}
+
+				results, err := generator.Array(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected array method error: %s", err)
+				}
+
+				for _, id := range results {
+					for _, mistyped := range mistypedIds(id, charList) {
+						valid, err := Verify(mistyped, charList, algorithm)
+						if err != nil || valid {
+							t.Errorf("expected %s mistyped as %s to be invalid, got %t, %v", id, mistyped, valid, err)
+						}
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("returns error when algorithm does not support char list", func(t *testing.T) {
+		testCases := []struct {
+			charList  []byte
+			algorithm CheckAlgorithm
+		}{
+			{charsAlphabetic, CheckDamm},
+			{charsAB, CheckDamm},
+			{charsAlphanumeric[:30], CheckISO7064Pure},
+			{charsAB, CheckAlgorithm(-1)},
+			{nil, CheckLuhn},
+		}
+
+		for _, tc := range testCases {
+			if _, err := Verify([]byte("AB"), tc.charList, tc.algorithm); !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for %d chars, got %v", len(tc.charList), err)
+			}
+		}
+	})
+}
+
+func TestGenerator_CheckCharacters(t *testing.T) {
+	t.Run("returns ids with valid check characters", func(t *testing.T) {
+		for _, algorithm := range []CheckAlgorithm{CheckLuhn, CheckDamm, CheckISO7064Hybrid, CheckISO7064Pure} {
+			generator, err := New(
+				WithCount(1000), WithLength(3), WithCharList(charsDigitsLetters), WithSeed(1), WithCheckCharacters(algorithm),
+			)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			results, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+
+			uniqueIds := make(map[string]struct{}, len(results))
+			for _, id := range results {
+				valid, err := Verify(id, charsDigitsLetters, algorithm)
+				if err != nil || !valid || len(id) < 4 {
+					t.Errorf("expected valid id with check characters, got %s, %v", id, err)
+				}
+				uniqueIds[string(id[:3])] = struct{}{}
+			}
+
+			if len(uniqueIds) != 1000 {
+				t.Errorf("expected %d unique ids, got %d", 1000, len(uniqueIds))
+			}
+
+			index, err := generator.IndexOf(context.Background(), results[500])
+			if err != nil || index != 500 {
+				t.Errorf("expected index 500 of %s, got %d, %v", results[500], index, err)
+			}
+
+			mistyped := append([]byte{}, results[500]...)
+			mistyped[0] = charsDigitsLetters[(bytes36Index(mistyped[0])+1)%36]
+			if _, err = generator.Decode(mistyped); !errors.Is(err, ErrInvalidID) {
+				t.Errorf("expected invalid id error for %s, got %v", mistyped, err)
+			}
+		}
+	})
+
+	t.Run("appends check symbols", func(t *testing.T) {
+		generator, err := New(WithCount(20), WithLength(2), WithSymbols(Runes("αβγδε")), WithCheckCharacters(CheckLuhn))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		for _, id := range results {
+			if _, err = generator.Decode(id); err != nil {
+				t.Errorf("unexpected decode method error for %s: %s", id, err)
+			}
+		}
+	})
+
+	t.Run("returns error when combined with template", func(t *testing.T) {
+		_, err := New(WithCount(1), WithTemplate("[0-9]"), WithCheckCharacters(CheckLuhn))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+func mistypedIds(id, charList []byte) [][]byte {
+	var mistyped [][]byte
+	for i := range id {
+		for _, char := range charList {
+			if char != id[i] {
+				substituted := append([]byte{}, id...)
+				substituted[i] = char
+				mistyped = append(mistyped, substituted)
+			}
+		}
+
+		if i+1 < len(id) && id[i] != id[i+1] {
+			transposed := append([]byte{}, id...)
+			transposed[i], transposed[i+1] = transposed[i+1], transposed[i]
+			mistyped = append(mistyped, transposed)
+		}
+	}
+
+	return mistyped
+}
+
+func bytes36Index(char byte) int {
+	for i, c := range charsDigitsLetters {
+		if c == char {
+			return i
+		}
+	}
+	return -1
+}
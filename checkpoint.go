@@ -0,0 +1,411 @@
+package generateids
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+const checkpointVersion = 1
+
+var ErrCheckpointCorrupted = errors.New("checkpoint corrupted")
+
+// Checkpoint describes the progress of a Generator. It can be marshaled to JSON or to a compact binary form
+// and passed to NewGeneratorFromCheckpoint to continue generating the same set of ids where the previous run stopped.
+// For generators with the sequential column schedule, the state of the columns is stored as well, so that they do not
+// have to be replayed from the seed. Other state is recreated from the seed and the number of ids generated.
+// For sharded generators, the number of ids generated is counted from the start of the shard.
+// Workers are set only for concurrent and sharded generators, which use the partitioned column schedule.
+// Character lists of every position are set instead of the character list for generators created
+// with WithCharLists, symbols are set instead of the character list for those created with WithSymbols,
+// and the template is set instead of both the id length and the character list for those created with WithTemplate.
+type Checkpoint struct {
+	IdsToGenerate int            `json:"idsToGenerate"`
+	IdLength      int            `json:"idLength"`
+	CharList      []byte         `json:"charList"`
+	CharLists     [][]byte       `json:"charLists,omitempty"`
+	Symbols       []string       `json:"symbols,omitempty"`
+	Template      string         `json:"template,omitempty"`
+	Seed          int64          `json:"seed"`
+	ShardIndex    int            `json:"shardIndex"`
+	TotalShards   int            `json:"totalShards"`
+	Workers       int            `json:"workers"`
+	Encoder       Encoder        `json:"encoder"`
+	Blocklist     Blocklist      `json:"blocklist"`
+	Check         CheckAlgorithm `json:"check,omitempty"`
+	IdsGenerated  int            `json:"idsGenerated"`
+	State         []byte         `json:"state,omitempty"`
+}
+
+// NewGeneratorFromCheckpoint is a constructor that continues generating the set of ids described by the checkpoint.
+// Array and Channel methods of the returned Generator return only the ids that were not generated before,
+// so together with the ids of the previous run they form exactly the same set as a single uninterrupted run.
+func NewGeneratorFromCheckpoint(c Checkpoint) (*Generator, error) {
+	opts := []Option{
+		WithCount(c.IdsToGenerate), WithSeed(c.Seed), WithEncoder(c.Encoder), WithBlocklist(c.Blocklist),
+		WithCheckCharacters(c.Check), withIdsGenerated(c.IdsGenerated),
+	}
+
+	switch {
+	case c.Template != "":
+		opts = append(opts, WithTemplate(c.Template))
+	case c.CharLists != nil:
+		opts = append(opts, WithCharLists(c.CharLists))
+	case c.Symbols != nil:
+		opts = append(opts, WithLength(c.IdLength), WithSymbols(c.Symbols))
+	default:
+		opts = append(opts, WithLength(c.IdLength), WithCharList(c.CharList))
+	}
+	if c.TotalShards > 1 {
+		opts = append(opts, WithShard(c.ShardIndex, c.TotalShards))
+	}
+	if c.Workers > 0 {
+		opts = append(opts, WithWorkers(c.Workers))
+	}
+	if len(c.State) > 0 {
+		opts = append(opts, withScheduleState(c.State))
+	}
+
+	return New(opts...)
+}
+
+// Checkpoint returns the current progress of the Generator. The number of ids generated includes the ids
+// of the run the Generator was created from, so it can be used both for interrupted and finished runs.
+// Returns an error wrapping errors.ErrUnsupported if the Generator has no seed, does not return ids
+// in a reproducible order or has an exclusion.
+func (g *Generator) Checkpoint() (Checkpoint, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.requireSeed(); err != nil {
+		return Checkpoint{}, err
+	}
+
+	if !g.ordered {
+		return Checkpoint{}, fmt.Errorf("%w: checkpoints require ids to be returned in order", errors.ErrUnsupported)
+	}
+
+	if g.exclusion != nil {
+		return Checkpoint{}, fmt.Errorf("%w: exclusions are not stored in checkpoints", errors.ErrUnsupported)
+	}
+
+	workers := 0
+	if g.partitioned {
+		workers = g.workers
+	}
+
+	idLength := g.idLength
+	var charList []byte
+	var charLists [][]byte
+	var symbols []string
+	switch {
+	case g.template != nil:
+		idLength = 0
+	case g.positional:
+		idLength = 0
+		charLists = make([][]byte, len(g.charLists))
+		for i, positionCharList := range g.charLists {
+			charLists[i] = make([]byte, len(positionCharList))
+			copy(charLists[i], positionCharList)
+		}
+	case g.symbols != nil:
+		symbols = make([]string, len(g.symbolList))
+		copy(symbols, g.symbolList)
+	default:
+		charList = make([]byte, len(g.charList))
+		copy(charList, g.charList)
+	}
+
+	return Checkpoint{
+		IdsToGenerate: g.idsScheduled,
+		IdLength:      idLength,
+		CharList:      charList,
+		CharLists:     charLists,
+		Symbols:       symbols,
+		Template:      g.pattern,
+		Seed:          g.seed,
+		ShardIndex:    g.shardIndex,
+		TotalShards:   g.totalShards,
+		Workers:       workers,
+		Encoder:       g.encoding,
+		Blocklist:     g.blockedTerms,
+		Check:         g.check,
+		IdsGenerated:  g.idsGenerated - g.shardStart,
+		State:         g.stateAt(g.idsGenerated),
+	}, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (c Checkpoint) MarshalBinary() ([]byte, error) {
+	data := []byte{checkpointVersion}
+	data = binary.AppendUvarint(data, uint64(c.IdsToGenerate))
+	data = binary.AppendUvarint(data, uint64(c.IdLength))
+	data = binary.AppendVarint(data, c.Seed)
+	data = binary.AppendUvarint(data, uint64(c.IdsGenerated))
+	data = binary.AppendUvarint(data, uint64(len(c.CharList)))
+	data = append(data, c.CharList...)
+	data = binary.AppendUvarint(data, uint64(c.ShardIndex))
+	data = binary.AppendUvarint(data, uint64(c.TotalShards))
+	data = binary.AppendUvarint(data, uint64(c.Workers))
+	data = binary.AppendUvarint(data, uint64(c.Encoder))
+	data = appendStrings(data, c.Symbols)
+	data = binary.AppendUvarint(data, uint64(len(c.Template)))
+	data = append(data, c.Template...)
+	data = appendStrings(data, c.CharLists)
+	data = appendStrings(data, c.Blocklist.Words)
+	data = appendStrings(data, c.Blocklist.Substrings)
+	data = binary.AppendUvarint(data, uint64(c.Check))
+	data = binary.AppendUvarint(data, uint64(len(c.State)))
+	data = append(data, c.State...)
+
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (c *Checkpoint) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != checkpointVersion {
+		return fmt.Errorf("%w: unsupported version", ErrCheckpointCorrupted)
+	}
+
+	r := &checkpointReader{data: data[1:]}
+	decoded := Checkpoint{
+		IdsToGenerate: r.readInt(),
+		IdLength:      r.readInt(),
+		Seed:          r.readInt64(),
+		IdsGenerated:  r.readInt(),
+		CharList:      r.readBytes(r.readInt()),
+		ShardIndex:    r.readInt(),
+		TotalShards:   r.readInt(),
+		Workers:       r.readInt(),
+		Encoder:       Encoder(r.readInt()),
+		Symbols:       r.readStrings(),
+		Template:      string(r.readBytes(r.readInt())),
+		CharLists:     r.readLists(),
+		Blocklist: Blocklist{
+			Words:      r.readStrings(),
+			Substrings: r.readStrings(),
+		},
+		Check: CheckAlgorithm(r.readInt()),
+		State: r.readBytes(r.readInt()),
+	}
+
+	if r.err != nil {
+		return fmt.Errorf("%w: %s", ErrCheckpointCorrupted, r.err)
+	}
+	if len(r.data) > 0 {
+		return fmt.Errorf("%w: %d unexpected trailing bytes", ErrCheckpointCorrupted, len(r.data))
+	}
+
+	*c = decoded
+	return nil
+}
+
+// stateInterval is the number of ids between the states of the column schedule saved for checkpoints,
+// in addition to the buffer size by which generating may run ahead of the ids returned.
+const stateInterval = 4096
+
+// skipDrawsBatchSize is the number of values drawn from the random number generator between context checks.
+const skipDrawsBatchSize = 1 << 20
+
+var (
+	errStateUnsupported  = errors.New("schedule state is stored only for sequential seeded generators")
+	errStateInconsistent = errors.New("schedule state does not match the checkpoint")
+)
+
+// scheduleState is the state of the sequential column schedule after the given number of ids. The random number
+// generator is restored by drawing the same number of values from the seed, which is much cheaper than
+// replaying the columns.
+type scheduleState struct {
+	idsGenerated int
+	draws        uint64
+	columns      []byte
+}
+
+func (s scheduleState) marshal() []byte {
+	data := binary.AppendUvarint(nil, uint64(s.idsGenerated))
+	data = binary.AppendUvarint(data, s.draws)
+	return append(data, s.columns...)
+}
+
+// saveState keeps the state of the column schedule after the given number of ids. Only the two latest states are
+// kept: they are more than the buffer size apart, so the ids returned never precede both of them.
+func (g *Generator) saveState(idsGenerated int, columns *internal.ColumnsGenerator) {
+	state := scheduleState{idsGenerated: idsGenerated, draws: g.counting.Draws(), columns: columns.AppendState(nil)}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.states = append(g.states, state)
+	if len(g.states) > 2 {
+		g.states = slices.Delete(g.states, 0, 1)
+	}
+}
+
+// stateAt returns the latest saved state of the column schedule that does not go past the given number of ids,
+// or nil if there is none and the schedule has to be replayed from the seed.
+func (g *Generator) stateAt(idsGenerated int) []byte {
+	for i := len(g.states) - 1; i >= 0; i-- {
+		if g.states[i].idsGenerated <= idsGenerated {
+			return g.states[i].marshal()
+		}
+	}
+	return nil
+}
+
+// restoreSchedule recreates the column schedule from the state stored in a checkpoint. The random number generator
+// is fast-forwarded only when generating starts, so that it can be interrupted with the context.
+func (g *Generator) restoreSchedule(data []byte) error {
+	if g.partitioned || g.counting == nil {
+		return errStateUnsupported
+	}
+
+	r := &checkpointReader{data: data}
+	state := scheduleState{idsGenerated: r.readInt(), draws: r.readUint64()}
+	if r.err != nil {
+		return r.err
+	}
+	state.columns = r.data
+
+	columns := internal.NewColumnsGenerator(g.random, g.scheduleSize, g.charLists)
+	err := columns.RestoreState(state.columns)
+	if err != nil {
+		return err
+	}
+
+	idsLeft := columns.IdsLeft()
+	switch {
+	case state.idsGenerated > g.idsGenerated, state.draws < g.counting.Draws(), idsLeft > g.scheduleSize:
+		return errStateInconsistent
+	case !g.filtered() && idsLeft != g.scheduleSize-state.idsGenerated:
+		return errStateInconsistent
+	}
+
+	g.restored = columns
+	g.restoredState = state
+	g.states = []scheduleState{state}
+	return nil
+}
+
+// skipDraws fast-forwards the random number generator to the given number of values drawn.
+func skipDraws(ctx context.Context, source *internal.CountingSource, draws uint64) error {
+	for source != nil && source.Draws() < draws {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		source.Skip(min(draws-source.Draws(), skipDrawsBatchSize))
+	}
+	return nil
+}
+
+func appendStrings[S ~string | ~[]byte](data []byte, values []S) []byte {
+	data = binary.AppendUvarint(data, uint64(len(values)))
+	for _, value := range values {
+		data = binary.AppendUvarint(data, uint64(len(value)))
+		data = append(data, value...)
+	}
+
+	return data
+}
+
+var errCheckpointTruncated = errors.New("truncated")
+
+type checkpointReader struct {
+	data []byte
+	err  error
+}
+
+func (r *checkpointReader) readInt() int {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 || value > math.MaxInt {
+		r.err = errCheckpointTruncated
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return int(value)
+}
+
+func (r *checkpointReader) readUint64() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errCheckpointTruncated
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *checkpointReader) readInt64() int64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = errCheckpointTruncated
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return value
+}
+
+func (r *checkpointReader) readBytes(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if len(r.data) < length {
+		r.err = errCheckpointTruncated
+		return nil
+	}
+
+	value := make([]byte, length)
+	copy(value, r.data)
+	r.data = r.data[length:]
+
+	return value
+}
+
+func (r *checkpointReader) readStrings() []string {
+	var values []string
+	for _, value := range r.readLists() {
+		values = append(values, string(value))
+	}
+
+	return values
+}
+
+func (r *checkpointReader) readLists() [][]byte {
+	count := r.readInt()
+	if count == 0 || r.err != nil {
+		return nil
+	}
+
+	if count > len(r.data) {
+		r.err = errCheckpointTruncated
+		return nil
+	}
+
+	charLists := make([][]byte, 0, count)
+	for i := 0; i < count && r.err == nil; i++ {
+		charLists = append(charLists, r.readBytes(r.readInt()))
+	}
+
+	return charLists
+}
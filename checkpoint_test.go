@@ -0,0 +1,237 @@
+package generateids
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestGenerator_Checkpoint(t *testing.T) {
+	t.Run("resumed generator returns the rest of the set", func(t *testing.T) {
+		seed := int64(0)
+		idsToGenerate := 10 * bufferSize
+		expected := generateIdsWithSeed(t, idsToGenerate, 32, charsAlphanumeric, seed)
+
+		generator, err := NewGeneratorWithSeed(idsToGenerate, 32, charsAlphanumeric, seed)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		idsChan, err := generator.Channel(ctx)
+		if err != nil {
+			t.Fatalf("unexpected channel method error: %s", err)
+		}
+
+		var results [][]byte
+		for id := range idsChan {
+			results = append(results, id)
+			if len(results) == bufferSize {
+				cancel()
+			}
+		}
+
+		if !errors.Is(generator.InterruptionErr(), context.Canceled) {
+			t.Fatalf("expected interruptionErr to be context error, got %v", generator.InterruptionErr())
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+		if checkpoint.IdsGenerated != len(results) {
+			t.Fatalf("expected %d ids generated, got %d", len(results), checkpoint.IdsGenerated)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		rest, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		results = append(results, rest...)
+		if len(results) != idsToGenerate {
+			t.Fatalf("expected %d results, got %d", idsToGenerate, len(results))
+		}
+
+		for index, id := range expected {
+			if string(id) != string(results[index]) {
+				t.Errorf("expected %s, got %s", id, results[index])
+			}
+		}
+
+		checkpoint, err = resumed.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+		if checkpoint.IdsGenerated != idsToGenerate {
+			t.Errorf("expected %d ids generated, got %d", idsToGenerate, checkpoint.IdsGenerated)
+		}
+	})
+
+	t.Run("resumed shard returns the rest of the shard", func(t *testing.T) {
+		seed := int64(0)
+		shard, err := NewShardedGeneratorWithSeed(100, 8, charsAlphanumeric, seed, 1, 3)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		checkpoint, err := shard.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		expected, err := shard.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		results, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		if len(results) != len(expected) {
+			t.Fatalf("expected %d results, got %d", len(expected), len(results))
+		}
+		for index, id := range expected {
+			if string(id) != string(results[index]) {
+				t.Errorf("expected %s, got %s", id, results[index])
+			}
+		}
+	})
+
+	t.Run("resumed generator continues from the stored schedule state", func(t *testing.T) {
+		blocklist := Blocklist{Substrings: []string{"aa"}}
+		opts := []Option{WithCount(5 * stateInterval), WithLength(10), WithCharList(charsABC), WithSeed(4), WithBlocklist(blocklist)}
+		expected := generateIdsWithOptions(t, opts...)
+
+		var results [][]byte
+		generator, err := New(opts...)
+		for _, stop := range []int{3*stateInterval + 7, 4 * stateInterval, len(expected)} {
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			ids, err := generator.Iterator(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected iterator method error: %s", err)
+			}
+			for len(results) < stop {
+				id, ok := ids.Next()
+				if !ok {
+					break
+				}
+				results = append(results, id)
+			}
+
+			checkpoint, err := generator.Checkpoint()
+			if err != nil {
+				t.Fatalf("unexpected checkpoint error: %s", err)
+			}
+			if len(checkpoint.State) == 0 {
+				t.Errorf("expected schedule state in checkpoint after %d ids", stop)
+			}
+
+			generator, err = NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		}
+
+		assertSameIds(t, expected, results)
+	})
+
+	t.Run("returns error when schedule state is corrupted", func(t *testing.T) {
+		opts := []Option{WithCount(10), WithLength(3), WithCharList(charsABC), WithSeed(5)}
+		generator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+		if _, err = generator.Array(context.Background()); err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+
+		state := checkpoint.State
+		for _, corrupted := range [][]byte{{0}, state[:len(state)-1], append(state, 0), {11, 0}} {
+			checkpoint.State = corrupted
+			_, err = NewGeneratorFromCheckpoint(checkpoint)
+			if !errors.Is(err, ErrCheckpointCorrupted) {
+				t.Errorf("expected checkpoint corrupted error for %v, got %v", corrupted, err)
+			}
+		}
+
+		checkpoint.State = state
+		checkpoint.Workers = 2
+		_, err = NewGeneratorFromCheckpoint(checkpoint)
+		if !errors.Is(err, ErrCheckpointCorrupted) {
+			t.Errorf("expected checkpoint corrupted error for concurrent generator, got %v", err)
+		}
+	})
+
+	t.Run("returns error when checkpoint is invalid", func(t *testing.T) {
+		_, err := NewGeneratorFromCheckpoint(Checkpoint{
+			IdsToGenerate: 4,
+			IdLength:      2,
+			CharList:      charsAB,
+			IdsGenerated:  5,
+		})
+
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("returns error when binary checkpoint is corrupted", func(t *testing.T) {
+		data, err := Checkpoint{IdsToGenerate: 4, IdLength: 2, CharList: charsAB}.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected marshal error: %s", err)
+		}
+
+		for _, corrupted := range [][]byte{nil, {0}, data[:len(data)-1], append(data, 'C')} {
+			var checkpoint Checkpoint
+			err = checkpoint.UnmarshalBinary(corrupted)
+			if !errors.Is(err, ErrCheckpointCorrupted) {
+				t.Errorf("expected checkpoint corrupted error for %v, got %v", corrupted, err)
+			}
+		}
+	})
+}
+
+func marshalCheckpoint(t *testing.T, checkpoint Checkpoint) Checkpoint {
+	jsonData, err := json.Marshal(checkpoint)
+	if err != nil {
+		t.Fatalf("unexpected json marshal error: %s", err)
+	}
+
+	var fromJSON Checkpoint
+	if err = json.Unmarshal(jsonData, &fromJSON); err != nil {
+		t.Fatalf("unexpected json unmarshal error: %s", err)
+	}
+
+	binaryData, err := fromJSON.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected binary marshal error: %s", err)
+	}
+
+	var fromBinary Checkpoint
+	if err = fromBinary.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("unexpected binary unmarshal error: %s", err)
+	}
+
+	return fromBinary
+}
@@ -0,0 +1,221 @@
+// Command generateids writes a set of unique ids in a randomized order to a file or to the standard output.
+//
+// Usage:
+//
+//	generateids -count 1000000 -length 16 -alphabet alphanumeric -format csv -output ids.csv
+//	generateids -count 1000 -template 'INV-[A-Z0-9]{4}-2025-[0-9]{4}'
+//
+// Exit codes distinguish the reasons of failure:
+//   - 1: other errors, e.g. failure to write the output,
+//   - 2: invalid flags or parameters rejected with generateids.ErrValidation,
+//   - 3: generator used more than once (generateids.ErrUsed),
+//   - 4: generating interrupted by the timeout or a signal.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+
+	"github.com/wfabjanczuk/generateids"
+)
+
+const (
+	exitFailure     = 1
+	exitValidation  = 2
+	exitUsed        = 3
+	exitInterrupted = 4
+)
+
+var presets = map[string]string{
+	"numeric":            generateids.AlphabetNumeric,
+	"hex":                generateids.AlphabetHex,
+	"alphabetic":         generateids.AlphabetAlphabetic,
+	"alphanumeric":       generateids.AlphabetAlphanumericUpper,
+	"alphanumeric-lower": generateids.AlphabetAlphanumericLower,
+	"alphanumeric-mixed": generateids.AlphabetAlphanumericMixed,
+	"crockford":          generateids.AlphabetCrockfordBase32,
+	"base32":             generateids.AlphabetBase32,
+	"base64url":          generateids.AlphabetBase64URL,
+	"nolookalikes":       generateids.AlphabetNoLookalikes,
+}
+
+var formats = map[string]generateids.Format{
+	"lines": generateids.FormatLines,
+	"csv":   generateids.FormatCSV,
+	"json":  generateids.FormatJSON,
+	"jsonl": generateids.FormatJSONLines,
+	"nul":   generateids.FormatNUL,
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+
+	os.Exit(code)
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generateids", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	count := flags.Int("count", 0, "number of ids to generate (required)")
+	length := flags.Int("length", 0, "length of each id (required)")
+	alphabet := flags.String("alphabet", "alphanumeric",
+		"characters to generate the ids from, or one of presets: "+strings.Join(sortedKeys(presets), ", "))
+	template := flags.String("template", "",
+		"pattern of the ids with literals and character classes, e.g. 'INV-[A-Z0-9]{4}-[0-9]{4}', instead of length and alphabet")
+	seed := flags.Int64("seed", 0, "seed for reproducible ids (default: current time)")
+	output := flags.String("output", "", "output file (default: standard output)")
+	format := flags.String("format", "lines", "output format: "+strings.Join(sortedKeys(formats), ", "))
+	timeout := flags.Duration("timeout", 0, "maximum duration of generating ids (default: no timeout)")
+	workers := flags.Int("workers", 0, "number of worker goroutines (default: sequential generator)")
+	exclude := flags.String("exclude", "", "file with ids to exclude, one per line, sorted in byte order")
+
+	if err := flags.Parse(args); err != nil {
+		return exitValidation
+	}
+
+	outputFormat, ok := formats[*format]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown format %q\n", *format)
+		return exitValidation
+	}
+
+	opts := []generateids.Option{
+		generateids.WithCount(*count),
+		generateids.WithLength(*length),
+	}
+	if isFlagSet(flags, "template") {
+		opts = append(opts, generateids.WithTemplate(*template))
+	}
+	// The default alphabet is not used with a template, but an explicit one is passed on to be rejected.
+	if !isFlagSet(flags, "template") || isFlagSet(flags, "alphabet") {
+		opts = append(opts, alphabetOption(*alphabet))
+	}
+	if isFlagSet(flags, "seed") {
+		opts = append(opts, generateids.WithSeed(*seed))
+	}
+	if *workers > 0 {
+		opts = append(opts, generateids.WithWorkers(*workers))
+	}
+
+	var exclusion *generateids.SortedFileExclusion
+	if *exclude != "" {
+		var err error
+		exclusion, err = generateids.OpenSortedFileExclusion(*exclude)
+		if err != nil {
+			return fail(stderr, err)
+		}
+		defer exclusion.Close()
+
+		opts = append(opts, generateids.WithExclusion(exclusion))
+	}
+
+	generator, err := generateids.New(opts...)
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	err = write(ctx, generator, *output, outputFormat, stdout)
+	if err == nil && exclusion != nil {
+		err = exclusion.Err()
+	}
+	if err != nil {
+		return fail(stderr, err)
+	}
+
+	return 0
+}
+
+func write(ctx context.Context, generator *generateids.Generator, output string, format generateids.Format, stdout io.Writer) error {
+	if output == "" {
+		_, err := generator.WriteAll(ctx, stdout, format)
+		return err
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+
+	_, err = generator.WriteAll(ctx, f, format)
+	closeErr := f.Close()
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+func fail(stderr io.Writer, err error) int {
+	fmt.Fprintln(stderr, err)
+
+	switch {
+	case errors.Is(err, generateids.ErrValidation):
+		return exitValidation
+	case errors.Is(err, generateids.ErrUsed):
+		return exitUsed
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return exitInterrupted
+	}
+
+	return exitFailure
+}
+
+func alphabetOption(alphabet string) generateids.Option {
+	if preset, ok := presets[alphabet]; ok {
+		alphabet = preset
+	}
+
+	if isASCII(alphabet) {
+		return generateids.WithCharList([]byte(alphabet))
+	}
+	return generateids.WithSymbols(generateids.Runes(alphabet))
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
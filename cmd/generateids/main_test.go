@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedCode int
+		expectedIds  int
+	}{
+		{"writes ids to standard output", []string{"-count", "100", "-length", "4"}, 0, 100},
+		{"writes ids with preset alphabet", []string{"-count", "10", "-length", "1", "-alphabet", "numeric"}, 0, 10},
+		{"writes ids with crockford alphabet", []string{"-count", "32", "-length", "1", "-alphabet", "crockford"}, 0, 32},
+		{"writes ids with concurrent generator", []string{"-count", "100", "-length", "4", "-workers", "2"}, 0, 100},
+		{"writes ids with template", []string{"-count", "100", "-template", "ID-[0-9]{2}"}, 0, 100},
+		{"returns validation code for template with alphabet", []string{"-count", "1", "-template", "[0-9]", "-alphabet", "hex"}, exitValidation, 0},
+		{"returns validation code for invalid parameters", []string{"-count", "11", "-length", "1", "-alphabet", "numeric"}, exitValidation, 0},
+		{"returns validation code for unknown flag", []string{"-unknown"}, exitValidation, 0},
+		{"returns validation code for unknown format", []string{"-count", "1", "-length", "1", "-format", "xml"}, exitValidation, 0},
+		{"returns interrupted code on timeout", []string{"-count", "1000000", "-length", "128", "-timeout", "1ms"}, exitInterrupted, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			code := run(context.Background(), tc.args, stdout, stderr)
+
+			if code != tc.expectedCode {
+				t.Fatalf("expected exit code %d, got %d: %s", tc.expectedCode, code, stderr)
+			}
+
+			idsCount := strings.Count(stdout.String(), "\n")
+			if tc.expectedIds >= 0 && idsCount != tc.expectedIds {
+				t.Errorf("expected %d ids, got %d", tc.expectedIds, idsCount)
+			}
+		})
+	}
+
+	t.Run("writes reproducible ids to output file", func(t *testing.T) {
+		var results [2]string
+		for i := range results {
+			output := filepath.Join(t.TempDir(), "ids.json")
+			args := []string{"-count", "100", "-length", "8", "-seed", "0", "-format", "json", "-output", output}
+
+			code := run(context.Background(), args, &bytes.Buffer{}, &bytes.Buffer{})
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d", code)
+			}
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("unexpected read error: %s", err)
+			}
+			results[i] = string(data)
+		}
+
+		if results[0] != results[1] || !strings.HasPrefix(results[0], "[") {
+			t.Errorf("expected the same json arrays, got %s and %s", results[0], results[1])
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package generateids
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// NewConcurrentGenerator is an alternative constructor that additionally requires the number of worker goroutines.
+// The set of ids is divided into partitions of at most a few thousand ids with separate column schedules,
+// which are generated and encoded by the workers in parallel. Ids are returned in the order the partitions
+// are finished, so the order may differ between runs even with the same seed.
+func NewConcurrentGenerator(idsToGenerate, idLength int, charList []byte, workers int) (*Generator, error) {
+	return New(
+		WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList),
+		WithWorkers(workers), WithOrderedMerge(false),
+	)
+}
+
+// NewConcurrentGeneratorWithSeed is an alternative constructor that additionally requires custom seed
+// and the number of worker goroutines. Partitions generated by the workers are merged in order,
+// so the returned ids are reproducible for the same seed regardless of the number of workers.
+// Because the partitions have separate column schedules, the ids differ from those returned by a Generator
+// created with NewGeneratorWithSeed and the same seed.
+func NewConcurrentGeneratorWithSeed(
+	idsToGenerate, idLength int, charList []byte, seed int64, workers int,
+) (*Generator, error) {
+	return New(
+		WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList), WithSeed(seed),
+		WithWorkers(workers),
+	)
+}
+
+type partitionJob struct {
+	partition     *internal.Partition
+	idsToSkip     int
+	idsToGenerate int
+	results       chan [][]byte
+}
+
+func (g *Generator) streamPartitionsToChannel(ctx context.Context, idsChan chan<- []byte) {
+	jobs := make(chan partitionJob)
+	pendingJobs := make(chan partitionJob, g.workers)
+	idsGenerated := g.idsGenerated
+	go g.dispatchPartitions(ctx, idsGenerated, jobs, pendingJobs)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(g.workers)
+	defer wg.Wait()
+
+	for i := 0; i < g.workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				job.results <- g.generatePartition(ctx, job)
+			}
+		}()
+	}
+
+	var interruptionErr error
+	for job := range pendingJobs {
+		ids := <-job.results
+		if interruptionErr != nil {
+			continue
+		}
+
+		for _, id := range ids {
+			if interruptionErr = g.interruption(ctx); interruptionErr != nil {
+				break
+			}
+
+			if !send(ctx, idsChan, id) {
+				interruptionErr = ctx.Err()
+				break
+			}
+			idsGenerated++
+			g.setIdsGenerated(idsGenerated)
+		}
+
+		if interruptionErr == nil && len(ids) < job.idsToGenerate {
+			interruptionErr = g.interruption(ctx)
+		}
+	}
+
+	if interruptionErr == nil {
+		interruptionErr = g.interruption(ctx)
+	}
+	if interruptionErr != nil && idsGenerated < g.shardEnd {
+		g.setInterruptionErr(idsGenerated, interruptionErr)
+	}
+}
+
+// dispatchPartitions sends every partition overlapping the range of ids left to generate to the workers.
+// Pending jobs are sent in order before the workers receive them, so that the merge can follow the order
+// of partitions or, if the order does not matter, take whichever partition is finished first.
+func (g *Generator) dispatchPartitions(ctx context.Context, idsGenerated int, jobs, pendingJobs chan<- partitionJob) {
+	defer close(jobs)
+	defer close(pendingJobs)
+
+	sharedResults := make(chan [][]byte, g.workers+1)
+	partitionsGen := internal.NewPartitionsGenerator(g.random, g.scheduleSize, g.charLists)
+
+	partitionStart := 0
+	for partitionStart < g.shardEnd && g.interruption(ctx) == nil {
+		partition := partitionsGen.Next()
+		partitionEnd := partitionStart + partition.Size
+
+		if partitionEnd > idsGenerated {
+			job := partitionJob{
+				partition:     partition,
+				idsToSkip:     max(idsGenerated-partitionStart, 0),
+				idsToGenerate: min(partitionEnd, g.shardEnd) - max(partitionStart, idsGenerated),
+				results:       sharedResults,
+			}
+			if g.ordered {
+				job.results = make(chan [][]byte, 1)
+			}
+
+			pendingJobs <- job
+			jobs <- job
+		}
+
+		partitionStart = partitionEnd
+	}
+}
+
+func (g *Generator) generatePartition(ctx context.Context, job partitionJob) [][]byte {
+	job.partition.Skip(job.idsToSkip)
+
+	ids := make([][]byte, 0, job.idsToGenerate)
+	for i := 0; i < job.idsToGenerate; i++ {
+		if g.interruption(ctx) != nil {
+			break
+		}
+
+		id := make([]byte, g.idLength)
+		job.partition.Next(id)
+		if g.sourceErr() != nil {
+			break
+		}
+		ids = append(ids, g.finishId(id))
+	}
+
+	return ids
+}
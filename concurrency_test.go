@@ -0,0 +1,193 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestConcurrentGenerator_Seed(t *testing.T) {
+	constructorArgumentSets := []constructorArguments{
+		{5, 1, charsAlphanumeric},
+		{1000, 7, charsABC},
+		{8192, 13, charsAB},
+		{100000, 20, charsAB},
+		{20000, 16, charsAlphanumeric},
+	}
+
+	for _, args := range constructorArgumentSets {
+		runConcurrentSeedTest(t, args.idsToGenerate, args.idLength, args.charList)
+	}
+}
+
+func runConcurrentSeedTest(t *testing.T, idsToGenerate, idLength int, charList []byte) {
+	testName := fmt.Sprintf("returns the same unique IDs regardless of workers for %d idsToGenerate "+
+		"with %d idLength and %d total chars", idsToGenerate, idLength, len(charList),
+	)
+
+	t.Run(testName, func(t *testing.T) {
+		seed := int64(0)
+		expected := generateConcurrentIdsWithSeed(t, idsToGenerate, idLength, charList, seed, 1)
+
+		uniqueIDs := make(map[string]struct{})
+		for _, id := range expected {
+			_, exists := uniqueIDs[string(id)]
+			if exists {
+				t.Errorf("expected unique IDs, got duplicated %s", id)
+			}
+			uniqueIDs[string(id)] = struct{}{}
+		}
+
+		for _, workers := range []int{2, 3, 8} {
+			results := generateConcurrentIdsWithSeed(t, idsToGenerate, idLength, charList, seed, workers)
+			for index, id := range expected {
+				if string(id) != string(results[index]) {
+					t.Fatalf("expected %s at %d with %d workers, got %s", id, index, workers, results[index])
+				}
+			}
+		}
+
+		generator, err := NewConcurrentGenerator(idsToGenerate, idLength, charList, 4)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		unorderedIDs := make(map[string]struct{})
+		for _, id := range results {
+			unorderedIDs[string(id)] = struct{}{}
+		}
+		if len(unorderedIDs) != idsToGenerate {
+			t.Errorf("expected %d unique IDs without ordered merge, got %d", idsToGenerate, len(unorderedIDs))
+		}
+	})
+}
+
+func generateConcurrentIdsWithSeed(t *testing.T, idsToGenerate, idLength int, charList []byte, seed int64, workers int) [][]byte {
+	generator, err := NewConcurrentGeneratorWithSeed(idsToGenerate, idLength, charList, seed, workers)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %s", err)
+	}
+
+	idsArray, err := generator.Array(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected array method error: %s", err)
+	}
+
+	if len(idsArray) != idsToGenerate {
+		t.Fatalf("expected %d results, got %d", idsToGenerate, len(idsArray))
+	}
+
+	return idsArray
+}
+
+func TestConcurrentGenerator_At(t *testing.T) {
+	t.Run("returns the same IDs as array", func(t *testing.T) {
+		seed := int64(0)
+		idsToGenerate := 20000
+		expected := generateConcurrentIdsWithSeed(t, idsToGenerate, 16, charsAlphanumeric, seed, 4)
+
+		generator, err := NewConcurrentGeneratorWithSeed(idsToGenerate, 16, charsAlphanumeric, seed, 4)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for index := 0; index < idsToGenerate; index += 997 {
+			id, err := generator.At(context.Background(), index)
+			if err != nil {
+				t.Fatalf("unexpected at method error: %s", err)
+			}
+
+			if string(id) != string(expected[index]) {
+				t.Errorf("expected %s at %d, got %s", expected[index], index, id)
+			}
+		}
+	})
+}
+
+func TestConcurrentGenerator_Checkpoint(t *testing.T) {
+	t.Run("resumed generator returns the rest of the set", func(t *testing.T) {
+		seed := int64(0)
+		idsToGenerate := 20000
+		expected := generateConcurrentIdsWithSeed(t, idsToGenerate, 16, charsAlphanumeric, seed, 4)
+
+		generator, err := NewConcurrentGeneratorWithSeed(idsToGenerate, 16, charsAlphanumeric, seed, 4)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		idsChan, err := generator.Channel(ctx)
+		if err != nil {
+			t.Fatalf("unexpected channel method error: %s", err)
+		}
+
+		var results [][]byte
+		for id := range idsChan {
+			results = append(results, id)
+			if len(results) == 5000 {
+				cancel()
+			}
+		}
+
+		if !errors.Is(generator.InterruptionErr(), context.Canceled) {
+			t.Fatalf("expected interruptionErr to be context error, got %v", generator.InterruptionErr())
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		rest, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		results = append(results, rest...)
+		if len(results) != idsToGenerate {
+			t.Fatalf("expected %d results, got %d", idsToGenerate, len(results))
+		}
+
+		for index, id := range expected {
+			if string(id) != string(results[index]) {
+				t.Fatalf("expected %s at %d, got %s", id, index, results[index])
+			}
+		}
+	})
+
+	t.Run("returns error when ids are not returned in order", func(t *testing.T) {
+		generator, err := NewConcurrentGenerator(1000, 16, charsAlphanumeric, 4)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		_, err = generator.Checkpoint()
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error, got %v", err)
+		}
+	})
+}
+
+func TestConcurrentGenerator_Validation(t *testing.T) {
+	t.Run("returns error when workers is not positive", func(t *testing.T) {
+		for _, workers := range []int{-1, 0} {
+			_, err := NewConcurrentGenerator(1000, 16, charsAlphanumeric, workers)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for %d workers, got %v", workers, err)
+			}
+		}
+	})
+}
@@ -12,7 +12,7 @@ import (
 func main() {
 	now := time.Now()
 
-	alphanumericCharList := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	alphanumericCharList := []byte(generateids.AlphabetAlphanumericUpper)
 	generator, err := generateids.NewGenerator(math.MaxInt, 128, alphanumericCharList)
 	if err != nil {
 		panic(err)
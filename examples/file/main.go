@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"context"
 	"fmt"
 	"os"
@@ -16,7 +15,7 @@ func main() {
 		fmt.Printf("duration: %v\n", time.Since(now))
 	}()
 
-	alphanumericCharList := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+	alphanumericCharList := []byte(generateids.AlphabetAlphanumericUpper)
 	generator, err := generateids.NewGenerator(1_000_000, 128, alphanumericCharList)
 	check(err)
 
@@ -24,24 +23,12 @@ func main() {
 	//ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	//defer cancel()
 
-	idsChan, err := generator.Channel(ctx)
-	check(err)
-
 	f, err := os.Create("results.txt")
 	check(err)
 	defer f.Close()
 
-	bufWriter := bufio.NewWriter(f)
-	for id := range idsChan {
-		_, err = bufWriter.Write(id)
-		check(err)
-
-		_, err = bufWriter.WriteString("\n")
-		check(err)
-	}
-
-	check(bufWriter.Flush())
-	check(generator.InterruptionErr())
+	_, err = generator.WriteAll(ctx, f, generateids.FormatLines)
+	check(err)
 }
 
 func check(err error) {
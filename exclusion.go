@@ -0,0 +1,188 @@
+package generateids
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	ErrExclusionUnsorted = errors.New("exclusion file is not sorted")
+	ErrExclusionExceeded = errors.New("exclusion contains more ids than its length")
+)
+
+// Exclusion is a set of ids which must not be returned by the Generator, e.g. ids issued by earlier runs
+// or other tools. Contains is called with every generated id and must not modify or retain it.
+// It may be called concurrently. Len returns the maximum number of ids which Contains reports as excluded;
+// it is used to validate up front that enough ids are left, and to extend the column schedule accordingly.
+type Exclusion interface {
+	Contains(id []byte) bool
+	Len() int
+}
+
+// ExclusionSet is an in-memory Exclusion.
+type ExclusionSet map[string]struct{}
+
+// NewExclusionSet returns an in-memory Exclusion of the given ids.
+func NewExclusionSet(ids [][]byte) ExclusionSet {
+	set := make(ExclusionSet, len(ids))
+	for _, id := range ids {
+		set[string(id)] = struct{}{}
+	}
+
+	return set
+}
+
+func (s ExclusionSet) Contains(id []byte) bool {
+	_, ok := s[string(id)]
+	return ok
+}
+
+func (s ExclusionSet) Len() int {
+	return len(s)
+}
+
+// ExclusionFunc returns an Exclusion calling the predicate for every generated id, e.g. to query a database.
+// The predicate must not report more than maxExcluded ids as excluded, and must give the same answer
+// for the same id, so that methods replaying the set remain consistent. If it excludes more ids, generating
+// may run out of ids and stop with ErrExclusionExceeded.
+func ExclusionFunc(predicate func(id []byte) bool, maxExcluded int) Exclusion {
+	return exclusionFunc{predicate: predicate, maxExcluded: maxExcluded}
+}
+
+type exclusionFunc struct {
+	predicate   func(id []byte) bool
+	maxExcluded int
+}
+
+func (e exclusionFunc) Contains(id []byte) bool {
+	return e.predicate(id)
+}
+
+func (e exclusionFunc) Len() int {
+	return e.maxExcluded
+}
+
+// SortedFileExclusion is an Exclusion of ids stored in a file, one per line, sorted in increasing byte order.
+// Only the offsets of the lines are kept in memory, and the ids are found with binary search in the file.
+type SortedFileExclusion struct {
+	file    *os.File
+	offsets []int64
+
+	mu  sync.Mutex
+	err error
+}
+
+// OpenSortedFileExclusion opens the file and indexes its lines, checking that they are sorted.
+// Returns ErrExclusionUnsorted if they are not. The returned Exclusion must be closed after generating ids.
+func OpenSortedFileExclusion(path string) (*SortedFileExclusion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets, err := indexSortedLines(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &SortedFileExclusion{file: file, offsets: offsets}, nil
+}
+
+func indexSortedLines(r io.Reader) ([]int64, error) {
+	var offsets []int64
+	var previous []byte
+	offset, advance := int64(0), 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		n, token, err := bufio.ScanLines(data, atEOF)
+		advance = n
+
+		return n, token, err
+	})
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(offsets) > 0 && bytes.Compare(previous, line) > 0 {
+			return nil, fmt.Errorf("%w: line %d", ErrExclusionUnsorted, len(offsets)+1)
+		}
+
+		offsets = append(offsets, offset)
+		offset += int64(advance)
+		previous = append(previous[:0], line...)
+	}
+
+	return offsets, scanner.Err()
+}
+
+func (e *SortedFileExclusion) Contains(id []byte) bool {
+	low, high := 0, len(e.offsets)
+	for low < high {
+		mid := (low + high) / 2
+
+		line, err := e.line(mid)
+		if err != nil {
+			e.setErr(err)
+			return false
+		}
+
+		switch bytes.Compare(line, id) {
+		case 0:
+			return true
+		case -1:
+			low = mid + 1
+		default:
+			high = mid
+		}
+	}
+
+	return false
+}
+
+func (e *SortedFileExclusion) line(index int) ([]byte, error) {
+	end := e.offsets[index] + int64(bufio.MaxScanTokenSize)
+	if index+1 < len(e.offsets) {
+		end = e.offsets[index+1]
+	}
+
+	buf := make([]byte, end-e.offsets[index])
+	n, err := e.file.ReadAt(buf, e.offsets[index])
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	line, _, _ := bytes.Cut(buf[:n], []byte{'\n'})
+	return bytes.TrimSuffix(line, []byte{'\r'}), nil
+}
+
+func (e *SortedFileExclusion) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// Err returns the first error of reading the file after it was opened. Ids looked up after a failed read
+// are not reported as excluded, so the error should be checked once ids are generated.
+func (e *SortedFileExclusion) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.err
+}
+
+func (e *SortedFileExclusion) Len() int {
+	return len(e.offsets)
+}
+
+func (e *SortedFileExclusion) Close() error {
+	return e.file.Close()
+}
@@ -0,0 +1,129 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_Exclusion(t *testing.T) {
+	previous := generateIdsWithSeed(t, 20, 5, charsAB, 1)
+	var previousLines []string
+	for _, id := range previous {
+		previousLines = append(previousLines, string(id))
+	}
+	slices.Sort(previousLines)
+
+	path := filepath.Join(t.TempDir(), "excluded.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(previousLines, "\r\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("unexpected write error: %s", err)
+	}
+
+	fileExclusion, err := OpenSortedFileExclusion(path)
+	if err != nil {
+		t.Fatalf("unexpected open error: %s", err)
+	}
+	defer fileExclusion.Close()
+
+	exclusions := map[string]Exclusion{
+		"set":  NewExclusionSet(previous),
+		"file": fileExclusion,
+		"func": ExclusionFunc(func(id []byte) bool {
+			return slices.Contains(previousLines, string(id))
+		}, len(previous)),
+	}
+
+	for name, exclusion := range exclusions {
+		t.Run("returns all ids left after "+name+" exclusion", func(t *testing.T) {
+			for _, workers := range []int{0, 2} {
+				opts := []Option{WithCount(12), WithLength(5), WithCharList(charsAB), WithExclusion(exclusion)}
+				if workers > 0 {
+					opts = append(opts, WithWorkers(workers))
+				}
+
+				results := generateIdsWithOptions(t, opts...)
+				if len(results) != 12 {
+					t.Errorf("expected %d results, got %d", 12, len(results))
+				}
+
+				uniqueIds := make(map[string]struct{}, len(results))
+				for _, id := range results {
+					if exclusion.Contains(id) {
+						t.Errorf("unexpected excluded id %s", id)
+					}
+					uniqueIds[string(id)] = struct{}{}
+				}
+
+				if len(uniqueIds) != 12 {
+					t.Errorf("expected %d unique ids, got %d", 12, len(uniqueIds))
+				}
+
+				_, err := New(append(opts, WithCount(13))...)
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("expected validation error for %d ids, got %v", 13, err)
+				}
+			}
+		})
+	}
+
+	if err = fileExclusion.Err(); err != nil {
+		t.Errorf("unexpected exclusion file error: %s", err)
+	}
+
+	t.Run("returns error when file is not sorted", func(t *testing.T) {
+		unsortedPath := filepath.Join(t.TempDir(), "unsorted.txt")
+		if err := os.WriteFile(unsortedPath, []byte("B\nA\n"), 0o600); err != nil {
+			t.Fatalf("unexpected write error: %s", err)
+		}
+
+		if _, err := OpenSortedFileExclusion(unsortedPath); !errors.Is(err, ErrExclusionUnsorted) {
+			t.Errorf("expected unsorted error, got %v", err)
+		}
+	})
+
+	t.Run("returns error when exclusion contains more ids than its length", func(t *testing.T) {
+		exclusion := ExclusionFunc(func(id []byte) bool { return id[0] == 'A' }, 0)
+		opts := []Option{WithCount(32), WithLength(5), WithCharList(charsAB), WithSeed(0), WithExclusion(exclusion)}
+
+		generator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if !errors.Is(err, ErrExclusionExceeded) {
+			t.Errorf("expected exclusion exceeded error, got %v", err)
+		}
+		if len(results) != 16 {
+			t.Errorf("expected 16 ids not excluded, got %d", len(results))
+		}
+
+		generator, err = New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if _, err = generator.At(context.Background(), 20); !errors.Is(err, ErrExclusionExceeded) {
+			t.Errorf("expected exclusion exceeded error from at method, got %v", err)
+		}
+		if _, err = generator.IndexOf(context.Background(), []byte("AAAAA")); !errors.Is(err, ErrExclusionExceeded) {
+			t.Errorf("expected exclusion exceeded error from index of method, got %v", err)
+		}
+	})
+
+	t.Run("does not support checkpoints", func(t *testing.T) {
+		exclusion := NewExclusionSet(previous)
+		generator, err := New(WithCount(1), WithLength(5), WithCharList(charsAB), WithSeed(0), WithExclusion(exclusion))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if _, err = generator.Checkpoint(); !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error, got %v", err)
+		}
+	})
+}
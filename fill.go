@@ -0,0 +1,104 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// filler keeps the state of the Fill and FillFlat methods between calls.
+type filler struct {
+	seq     *sequence
+	columns []byte
+}
+
+// Fill writes the next ids into the buffers owned by the caller, so that they can be reused between calls,
+// e.g. recycled from a sync.Pool. Every buffer is overwritten from the start and grown only if its capacity
+// is too small for the id. Ids are generated synchronously in the calling goroutine, continuing from
+// the previous call. The first call uses the Generator like Array and Channel methods, so they cannot be mixed.
+// Fill is not safe for concurrent use.
+//
+// It returns the number of ids written, which is less than len(dst) once all ids are generated.
+// If generating is interrupted, the returned error is the same as from the InterruptionErr method.
+func (g *Generator) Fill(ctx context.Context, dst [][]byte) (int, error) {
+	f, err := g.startFilling(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range dst {
+		if !f.seq.nextColumns(ctx, f.columns) {
+			return i, g.InterruptionErr()
+		}
+
+		dst[i] = g.appendId(dst[i][:0], f.columns)
+	}
+
+	return len(dst), nil
+}
+
+// FillFlat is an alternative to Fill, which writes the next ids one after another into a single buffer,
+// as many as it fits. Every id takes IDSize bytes, so the i-th id is dst[i*size : (i+1)*size].
+// Returns an error wrapping errors.ErrUnsupported if the ids have different sizes, and ErrValidation
+// if dst does not fit a single id, in which case the Generator is not used.
+func (g *Generator) FillFlat(ctx context.Context, dst []byte) (int, error) {
+	size := g.IDSize()
+	if size == 0 {
+		return 0, fmt.Errorf("%w: ids consist of symbols of different sizes", errors.ErrUnsupported)
+	}
+	if len(dst) < size {
+		return 0, fmt.Errorf("%w: buffer of %d bytes does not fit an id of %d bytes", ErrValidation, len(dst), size)
+	}
+
+	f, err := g.startFilling(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(dst)/size; i++ {
+		if !f.seq.nextColumns(ctx, f.columns) {
+			return i, g.InterruptionErr()
+		}
+
+		g.appendId(dst[i*size:i*size], f.columns)
+	}
+
+	return len(dst) / size, nil
+}
+
+// IDSize returns the number of bytes of every id, including literals of the template and check characters,
+// or 0 if the ids have different sizes, which is the case for symbols of different sizes.
+func (g *Generator) IDSize() int {
+	switch {
+	case g.template != nil:
+		return len(g.template.Render(make([]byte, g.idLength)))
+	case g.symbols != nil:
+		size := len(g.symbolList[0])
+		for _, symbol := range g.symbolList {
+			if len(symbol) != size {
+				return 0
+			}
+		}
+		return size * (g.idLength + g.checkLength())
+	}
+
+	return g.idLength + g.checkLength()
+}
+
+// startFilling uses the Generator on the first call of Fill or FillFlat, and returns the state of the previous calls later.
+func (g *Generator) startFilling(ctx context.Context) (*filler, error) {
+	if g.filling != nil {
+		return g.filling, nil
+	}
+
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	g.filling = &filler{
+		seq:     g.newSequence(),
+		columns: make([]byte, g.idLength, g.idLength+g.checkLength()),
+	}
+	return g.filling, nil
+}
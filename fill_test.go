@@ -0,0 +1,206 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGenerator_Fill(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{"sequential", []Option{WithCount(1000), WithLength(8), WithCharList(charsAlphanumeric)}},
+		{"concurrent", []Option{WithCount(10_000), WithLength(8), WithCharList(charsAlphanumeric), WithWorkers(4)}},
+		{"template", []Option{WithCount(500), WithTemplate("ID-[A-Z]{3}")}},
+		{"symbols", []Option{WithCount(500), WithLength(4), WithSymbols([]string{"a", "bb", "ccc", "δ", "€", "🙂"})}},
+		{"check characters", []Option{WithCount(500), WithLength(4), WithCharList(charsDigits), WithCheckCharacters(CheckISO7064Pure)}},
+		{"blocklist", []Option{WithCount(500), WithLength(3), WithCharList(charsDigits), WithBlocklist(Blocklist{Substrings: []string{"13"}})}},
+		{"shard", []Option{WithCount(1000), WithLength(4), WithCharList(charsAlphanumeric), WithShard(2, 3)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(tc.opts, WithSeed(11))
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			buffers := make([][]byte, 64)
+			var results [][]byte
+			for {
+				n, err := generator.Fill(context.Background(), buffers)
+				if err != nil {
+					t.Fatalf("unexpected fill method error: %s", err)
+				}
+
+				for _, id := range buffers[:n] {
+					results = append(results, append([]byte(nil), id...))
+				}
+				if n < len(buffers) {
+					break
+				}
+			}
+
+			assertSameIds(t, expected, results)
+		})
+	}
+
+	t.Run("reuses buffers", func(t *testing.T) {
+		generator, err := New(WithCount(1000), WithLength(8), WithCharList(charsAlphanumeric), WithSeed(11))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		buffers := make([][]byte, 10)
+		if _, err = generator.Fill(context.Background(), buffers); err != nil {
+			t.Fatalf("unexpected fill method error: %s", err)
+		}
+
+		allocs := testing.AllocsPerRun(10, func() {
+			generator.Fill(context.Background(), buffers)
+		})
+		if allocs != 0 {
+			t.Errorf("expected no allocations per fill, got %f", allocs)
+		}
+	})
+
+	t.Run("reuses buffers across partitions", func(t *testing.T) {
+		generator, err := New(WithCount(1000000), WithLength(8), WithCharList(charsAlphanumeric), WithSeed(11), WithWorkers(4))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		buffers := make([][]byte, 1024)
+		for i := 0; i < 10; i++ {
+			if _, err = generator.Fill(context.Background(), buffers); err != nil {
+				t.Fatalf("unexpected fill method error: %s", err)
+			}
+		}
+
+		allocs := testing.AllocsPerRun(20, func() {
+			generator.Fill(context.Background(), buffers)
+		})
+		if allocs != 0 {
+			t.Errorf("expected no allocations per fill, got %f", allocs)
+		}
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		generator, err := New(WithCount(1000), WithLength(8), WithCharList(charsAlphanumeric), WithSeed(11))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		buffers := make([][]byte, 10)
+		if n, err := generator.Fill(ctx, buffers); n != 10 || err != nil {
+			t.Fatalf("expected 10 ids and no error, got %d and %v", n, err)
+		}
+
+		cancel()
+		if n, err := generator.Fill(ctx, buffers); n != 0 || !errors.Is(err, context.Canceled) {
+			t.Errorf("expected no ids and context error, got %d and %v", n, err)
+		}
+
+		if _, err = generator.Array(context.Background()); !errors.Is(err, ErrUsed) {
+			t.Errorf("expected used error, got %v", err)
+		}
+	})
+}
+
+func TestGenerator_FillFlat(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("returns the same ids as array with %d workers", workers), func(t *testing.T) {
+			opts := []Option{WithCount(5000), WithTemplate("[A-Z]{2}-[0-9]{4}"), WithSeed(12), WithWorkers(workers)}
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			size := generator.IDSize()
+			if size != 7 {
+				t.Fatalf("expected id size 7, got %d", size)
+			}
+
+			buffer := make([]byte, 100*size+3)
+			var results [][]byte
+			for {
+				n, err := generator.FillFlat(context.Background(), buffer)
+				if err != nil {
+					t.Fatalf("unexpected fill flat method error: %s", err)
+				}
+
+				for i := 0; i < n; i++ {
+					results = append(results, append([]byte(nil), buffer[i*size:(i+1)*size]...))
+				}
+				if n < 100 {
+					break
+				}
+			}
+
+			assertSameIds(t, expected, results)
+		})
+	}
+
+	t.Run("returns error for ids of different sizes", func(t *testing.T) {
+		generator, err := New(WithCount(5), WithLength(2), WithSymbols([]string{"a", "bb", "ccc"}))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if generator.IDSize() != 0 {
+			t.Errorf("expected no id size, got %d", generator.IDSize())
+		}
+
+		if _, err = generator.FillFlat(context.Background(), make([]byte, 100)); !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error, got %v", err)
+		}
+	})
+
+	t.Run("returns error when buffer does not fit an id", func(t *testing.T) {
+		generator, err := New(WithCount(5), WithLength(4), WithCharList(charsAB), WithSeed(13))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if _, err = generator.FillFlat(context.Background(), make([]byte, 3)); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil || len(results) != 5 {
+			t.Errorf("expected generator not to be used, got %d results, %v", len(results), err)
+		}
+	})
+}
+
+func TestGenerator_IDSize(t *testing.T) {
+	testCases := []struct {
+		opts     []Option
+		expected int
+	}{
+		{[]Option{WithLength(8), WithCharList(charsAlphanumeric)}, 8},
+		{[]Option{WithLength(8), WithCharList(charsDigits), WithCheckCharacters(CheckISO7064Pure)}, 10},
+		{[]Option{WithLength(3), WithSymbols(Runes("αβγ")), WithCheckCharacters(CheckLuhn)}, 8},
+		{[]Option{WithTemplate("INV-[0-9]{4}-X")}, 10},
+	}
+
+	for _, tc := range testCases {
+		generator, err := New(append(tc.opts, WithCount(1))...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if generator.IDSize() != tc.expected {
+			t.Errorf("expected id size %d, got %d", tc.expected, generator.IDSize())
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package generateids
 
 import (
+	"bytes"
 	"context"
+	cryptorand "crypto/rand"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/wfabjanczuk/generateids/internal"
 )
@@ -14,19 +18,59 @@ import (
 var (
 	ErrUsed       = errors.New("generator can be used only once: create a new instance for another set of ids")
 	ErrValidation = errors.New("validation error")
+	ErrInvalidID  = errors.New("invalid id")
+
+	ErrIndexOutOfRange = errors.New("index out of range")
+	ErrIDNotFound      = errors.New("id not found in the set")
 )
 
-const bufferSize = 100
+const (
+	bufferSize = 100
+	// arrayChunkSize is the minimum number of ids by which the Array method grows the array.
+	arrayChunkSize = 1 << 16
+	// sliceHeaderSize is the size of a []byte in the array returned by the Array method.
+	sliceHeaderSize = int(unsafe.Sizeof([]byte(nil)))
+)
 
 // Generator is a one-time use structure for generating a set of unique ids given their number, length
 // and list of characters (bytes). Provides Array and Channel methods that can be used depending on your needs.
 // To generate another set of ids, create a new instance of the Generator.
 type Generator struct {
+	seed            int64
+	source          *internal.ReaderSource
+	counting        *internal.CountingSource
 	random          *rand.Rand
-	encoder         *internal.SymmetricEncoder
+	encoding        Encoder
+	encoder         internal.Encoder
 	charList        []byte
+	charLists       [][]byte
+	positional      bool
+	symbols         *internal.SymbolTable
+	symbolList      []string
+	template        *internal.Template
+	pattern         string
 	idLength        int
 	idsScheduled    int
+	scheduleSize    int
+	blocklist       *internal.Blocklist
+	blockedTerms    Blocklist
+	exclusion       Exclusion
+	check           CheckAlgorithm
+	checksum        *internal.Checksum
+	shardIndex      int
+	totalShards     int
+	shardStart      int
+	shardEnd        int
+	partitioned     bool
+	workers         int
+	ordered         bool
+	bufferSize      int
+	memoryLimit     int
+	idsGenerated    int
+	restored        *internal.ColumnsGenerator
+	restoredState   scheduleState
+	states          []scheduleState
+	filling         *filler
 	used            bool
 	interruptionErr error
 	mu              sync.Mutex
@@ -36,31 +80,143 @@ type Generator struct {
 // and list of characters (bytes) to generate the ids from.
 // By default, internal random number generator is seeded with the current time in nanoseconds.
 func NewGenerator(idsToGenerate, idLength int, charList []byte) (*Generator, error) {
-	random := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return newGenerator(idsToGenerate, idLength, charList, random)
+	return New(WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList))
 }
 
 // NewGeneratorWithSeed is an alternative constructor that additionally requires custom seed
 // for the internal random number generator.
 func NewGeneratorWithSeed(idsToGenerate, idLength int, charList []byte, seed int64) (*Generator, error) {
-	random := rand.New(rand.NewSource(seed))
-	return newGenerator(idsToGenerate, idLength, charList, random)
+	return New(WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList), WithSeed(seed))
+}
+
+// NewShardedGeneratorWithSeed is an alternative constructor for splitting one set of ids between processes.
+// The set given by the number of ids to generate, length of each id, list of characters and seed is divided
+// into totalShards contiguous shards and the returned Generator generates only the shard with the given index,
+// counting from zero. Shards use the partitioned column schedule of concurrent generators, in which every partition
+// has its own seed, so a shard skips the partitions of the preceding shards without generating them.
+// Shards never overlap and together they form exactly the same set as a single Generator created
+// with NewConcurrentGeneratorWithSeed and the same seed, in the same order, regardless of the number of shards.
+// They do not reproduce the ids of NewGeneratorWithSeed, whose sequential schedule generates a different set
+// from the same seed.
+func NewShardedGeneratorWithSeed(
+	idsToGenerate, idLength int, charList []byte, seed int64, shardIndex, totalShards int,
+) (*Generator, error) {
+	return New(
+		WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList), WithSeed(seed),
+		WithShard(shardIndex, totalShards),
+	)
 }
 
-func newGenerator(idsToGenerate, idLength int, charList []byte, random *rand.Rand) (*Generator, error) {
-	err := internal.Validate(idsToGenerate, idLength, charList)
+// NewGeneratorWithReader is an alternative constructor that additionally requires a source of random bytes,
+// such as crypto/rand.Reader, for the internal random number generator. Ids generated this way cannot be guessed
+// from the start time, but there is no seed to replay the set from: At, IndexOf and Checkpoint methods
+// return errors. If reading from the source fails, generating ids is interrupted and the error is available
+// from the InterruptionErr method.
+func NewGeneratorWithReader(idsToGenerate, idLength int, charList []byte, randomReader io.Reader) (*Generator, error) {
+	return New(WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList), WithRandomReader(randomReader))
+}
+
+// NewSecureGenerator is an alternative constructor that uses crypto/rand.Reader as the source of randomness.
+// See NewGeneratorWithReader for details.
+func NewSecureGenerator(idsToGenerate, idLength int, charList []byte) (*Generator, error) {
+	return NewGeneratorWithReader(idsToGenerate, idLength, charList, cryptorand.Reader)
+}
+
+func newGenerator(c config) (*Generator, error) {
+	err := c.validate()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", ErrValidation, err)
 	}
 
-	return &Generator{
+	if !c.seeded {
+		c.seed = time.Now().UnixNano()
+	}
+
+	counting := internal.NewCountingSource(c.seed)
+	random := rand.New(counting)
+	var source *internal.ReaderSource
+	if c.randomReader != nil {
+		counting = nil
+		source = internal.NewReaderSource(c.randomReader)
+		random = rand.New(source)
+	}
+
+	shardStart, shardEnd := internal.ShardRange(c.idsToGenerate, c.shardIndex, c.totalShards)
+	g := &Generator{
+		seed:         c.seed,
+		source:       source,
+		counting:     counting,
 		random:       random,
-		encoder:      internal.NewSymmetricEncoder(random, idLength, charList),
-		charList:     charList,
-		idLength:     idLength,
-		idsScheduled: idsToGenerate,
+		encoding:     c.encoding,
+		charList:     c.charList,
+		idLength:     c.idLength,
+		idsScheduled: c.idsToGenerate,
+		scheduleSize: c.idsToGenerate,
+		shardIndex:   c.shardIndex,
+		totalShards:  c.totalShards,
+		shardStart:   shardStart,
+		shardEnd:     shardEnd,
+		partitioned:  c.partitioned,
+		workers:      c.workers,
+		ordered:      c.ordered,
+		bufferSize:   c.bufferSize,
+		memoryLimit:  c.memoryLimit,
+		idsGenerated: shardStart + c.idsGenerated,
 		used:         false,
-	}, nil
+	}
+
+	switch {
+	case c.templated:
+		g.template, _ = internal.ParseTemplate(c.template)
+		g.pattern = c.template
+		g.charLists = g.template.CharLists
+		g.idLength = len(g.charLists)
+	case c.charLists != nil:
+		g.charLists = c.charLists
+		g.positional = true
+		g.idLength = len(g.charLists)
+	case c.symbols != nil:
+		g.symbols = internal.NewSymbolTable(c.symbols)
+		g.symbolList = c.symbols
+		g.charList = g.symbols.Indices()
+		g.charLists = internal.RepeatCharList(g.charList, g.idLength)
+	default:
+		g.charLists = internal.RepeatCharList(g.charList, g.idLength)
+	}
+
+	if c.check != CheckNone {
+		g.check = c.check
+		g.checksum = internal.NewChecksum(int(c.check), g.charList)
+	}
+
+	if !c.blocklist.empty() || c.exclusion != nil {
+		err = g.setupFilters(c.blocklist, c.exclusion)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrValidation, err)
+		}
+	}
+
+	g.encoder = g.newEncoder(random)
+	if source != nil && source.Err() != nil {
+		return nil, fmt.Errorf("failed to read random source: %w", source.Err())
+	}
+
+	if c.state != nil {
+		err = g.restoreSchedule(c.state)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCheckpointCorrupted, err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Generator) newEncoder(random *rand.Rand) internal.Encoder {
+	if g.encoding == EncoderNone {
+		return internal.NoopEncoder{}
+	}
+
+	return internal.NewSymmetricEncoder(random, g.charLists)
 }
 
 // InterruptionErr will return wrapped context error, if the context passed to either Array or Channel
@@ -75,6 +231,301 @@ func (g *Generator) InterruptionErr() error {
 	return g.interruptionErr
 }
 
+// Decode reverses the encoding applied to every generated id and returns its pre-encoding column form,
+// in which each character comes directly from the column schedule. The passed id is left unchanged.
+// Returns ErrInvalidID if the id has a different length or contains characters outside the character list.
+func (g *Generator) Decode(id []byte) ([]byte, error) {
+	decoded, err := g.decodeColumns(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.render(decoded), nil
+}
+
+// decodeColumns returns the column form of the id, in which symbols are represented by their indices
+// and literals of the template are left out.
+func (g *Generator) decodeColumns(id []byte) ([]byte, error) {
+	decoded, err := g.parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidID, err)
+	}
+
+	g.encoder.Decode(decoded)
+	return decoded, nil
+}
+
+func (g *Generator) parse(id []byte) ([]byte, error) {
+	switch {
+	case g.template != nil:
+		return g.template.Parse(id)
+	case g.symbols != nil:
+		indices, err := g.symbols.Parse(id, g.idLength+g.checkLength())
+		if err != nil {
+			return nil, err
+		}
+
+		return g.stripCheck(indices)
+	}
+
+	charLists := g.charLists
+	if g.checksum != nil {
+		charLists = internal.RepeatCharList(g.charList, g.idLength+g.checkLength())
+	}
+
+	err := internal.ValidateID(id, charLists)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]byte, len(id))
+	copy(columns, id)
+
+	return g.stripCheck(columns)
+}
+
+// finishId encodes the id taken from the column schedule, appends check characters and, if the Generator
+// uses symbols or a template, replaces their indices with the symbols themselves or inserts the literals.
+func (g *Generator) finishId(id []byte) []byte {
+	g.encoder.Encode(id)
+	return g.render(g.appendCheck(id))
+}
+
+// appendId is an alternative to finishId, which appends the finished id to dst instead of allocating it.
+// The id taken from the column schedule is encoded in place and must have capacity for the check characters.
+func (g *Generator) appendId(dst, id []byte) []byte {
+	g.encoder.Encode(id)
+	id = g.appendCheck(id)
+
+	switch {
+	case g.template != nil:
+		return g.template.AppendRender(dst, id)
+	case g.symbols != nil:
+		return g.symbols.AppendRender(dst, id)
+	}
+
+	return append(dst, id...)
+}
+
+func (g *Generator) render(id []byte) []byte {
+	switch {
+	case g.template != nil:
+		return g.template.Render(id)
+	case g.symbols != nil:
+		return g.symbols.Render(id)
+	}
+
+	return id
+}
+
+// At returns the id that Array and Channel methods place at the given index, counting from zero.
+// It does not use the Generator, so it can be called any number of times, also before or after generating the set.
+// Predecessors of the id are not generated: every column draws from the same random number generator,
+// so only the character jobs of the columns preceding the id are drawn, in whole jobs rather than id by id.
+// Concurrent generators skip whole partitions instead, as every partition has its own seed, so the cost
+// does not depend on the number of ids before it. With a blocklist or an exclusion, positions of the ids
+// depend on the skipped ones, so the column schedule is replayed up to the index.
+// Returns ErrIndexOutOfRange if the index is negative or not less than the number of ids to generate.
+// For sharded generators, the index is counted from the start of the whole set, not the shard.
+func (g *Generator) At(ctx context.Context, index int) ([]byte, error) {
+	if err := g.requireSeed(); err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= g.idsScheduled {
+		return nil, fmt.Errorf("%w: %d not in [0, %d)", ErrIndexOutOfRange, index, g.idsScheduled)
+	}
+
+	id := make([]byte, g.idLength, g.idLength+g.checkLength())
+	if g.filtered() {
+		schedule, err := g.replaySchedule(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule.Next(id)
+		if err = scheduleErr(schedule); err != nil {
+			return nil, err
+		}
+		return g.finishId(id), nil
+	}
+
+	err := g.newColumnsSchedule(g.replayRandom()).At(ctx, index, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.finishId(id), nil
+}
+
+// IndexOf is the reverse of At method: it returns the index at which Array and Channel methods place the given id.
+// Like At, it draws only the parts of the column schedule preceding the id, summing up the numbers of ids
+// it skips. With a blocklist or an exclusion, the column schedule is replayed up to the id instead.
+// Returns ErrInvalidID if the id cannot be decoded and ErrIDNotFound if the id is not part of the set.
+func (g *Generator) IndexOf(ctx context.Context, id []byte) (int, error) {
+	if err := g.requireSeed(); err != nil {
+		return 0, err
+	}
+
+	decoded, err := g.decodeColumns(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if g.filtered() {
+		return g.replayIndexOf(ctx, id, decoded)
+	}
+
+	index, ok, err := g.newColumnsSchedule(g.replayRandom()).IndexOf(ctx, decoded)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrIDNotFound, id)
+	}
+	return index, nil
+}
+
+// replayIndexOf replays the column schedule until it reaches the decoded id, as positions of the ids
+// with a blocklist or an exclusion are counted in allowed ids only.
+func (g *Generator) replayIndexOf(ctx context.Context, id, decoded []byte) (int, error) {
+	schedule, err := g.replaySchedule(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	columnsId := make([]byte, g.idLength)
+	for index := 0; index < g.idsScheduled; index++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		schedule.Next(columnsId)
+		if err = scheduleErr(schedule); err != nil {
+			return 0, err
+		}
+		if bytes.Equal(columnsId, decoded) {
+			return index, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrIDNotFound, id)
+}
+
+// Contains returns true if the id is one of the ids specified in the Generator constructor, regardless of the shard.
+// Like IndexOf, it requires the seed and draws only the parts of the column schedule preceding the id,
+// without generating the ids themselves. Ids which cannot be decoded are not part of the set.
+// With a blocklist or an exclusion, positions of the ids depend on the skipped ones, so IndexOf is used instead.
+func (g *Generator) Contains(ctx context.Context, id []byte) (bool, error) {
+	if err := g.requireSeed(); err != nil {
+		return false, err
+	}
+
+	if g.filtered() {
+		_, err := g.IndexOf(ctx, id)
+		if errors.Is(err, ErrIDNotFound) || errors.Is(err, ErrInvalidID) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	decoded, err := g.decodeColumns(id)
+	if err != nil {
+		return false, nil
+	}
+
+	_, ok, err := g.newColumnsSchedule(g.replayRandom()).IndexOf(ctx, decoded)
+	return ok, err
+}
+
+// replayRandom recreates the random number generator from the seed, in the state in which the column schedule
+// is created.
+func (g *Generator) replayRandom() *rand.Rand {
+	random := rand.New(rand.NewSource(g.seed))
+	// the encoder is set up before the columns, so its shuffles have to be repeated to reach the same random state
+	g.newEncoder(random)
+
+	return random
+}
+
+// replaySchedule recreates the column schedule from the seed and advances it past the given number of ids.
+func (g *Generator) replaySchedule(ctx context.Context, idsToSkip int) (internal.Schedule, error) {
+	schedule := g.newSchedule(g.replayRandom())
+
+	err := skipSchedule(ctx, schedule, idsToSkip)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (g *Generator) requireSeed() error {
+	if g.source != nil {
+		return fmt.Errorf("%w: generator has no seed to replay the set from", errors.ErrUnsupported)
+	}
+	return nil
+}
+
+// columnsSchedule is the column schedule before blocked and excluded ids are skipped.
+type columnsSchedule interface {
+	internal.Schedule
+	At(ctx context.Context, index int, id []byte) error
+	IndexOf(ctx context.Context, id []byte) (int, bool, error)
+}
+
+func (g *Generator) newColumnsSchedule(random *rand.Rand) columnsSchedule {
+	if g.partitioned {
+		return internal.NewPartitionedColumnsGenerator(random, g.scheduleSize, g.charLists)
+	}
+	return internal.NewColumnsGenerator(random, g.scheduleSize, g.charLists)
+}
+
+func (g *Generator) newSchedule(random *rand.Rand) internal.Schedule {
+	return g.filter(g.newColumnsSchedule(random), g.scheduleSize)
+}
+
+// filter skips blocked and excluded ids of the column schedule with the given number of ids left,
+// if there are any filters.
+func (g *Generator) filter(schedule columnsSchedule, idsLeft int) internal.Schedule {
+	if g.filtered() {
+		return &filteredSchedule{
+			g:        g,
+			schedule: schedule,
+			idsLeft:  idsLeft,
+			encoded:  make([]byte, g.idLength, g.idLength+g.checkLength()),
+		}
+	}
+	return schedule
+}
+
+const skipBatchSize = 1024
+
+func skipSchedule(ctx context.Context, schedule internal.Schedule, idsToSkip int) error {
+	for idsToSkip > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batchSize := min(idsToSkip, skipBatchSize)
+		schedule.Skip(batchSize)
+		if err := scheduleErr(schedule); err != nil {
+			return err
+		}
+		idsToSkip -= batchSize
+	}
+
+	return nil
+}
+
+func (g *Generator) setIdsGenerated(idsGenerated int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.idsGenerated = idsGenerated
+}
+
 func (g *Generator) setInterruptionErr(idsGenerated int, err error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
@@ -83,21 +534,119 @@ func (g *Generator) setInterruptionErr(idsGenerated int, err error) {
 }
 
 // Array method generates the set of ids specified in the Generator constructor and saves them into an array.
+// The array grows as the ids are generated, so memory is not reserved up front for the whole set.
+// If WithArrayMemoryLimit is set and the limit is reached, the ids generated so far are returned
+// with an error wrapping MemoryLimitError, and generating can be resumed from the Checkpoint method.
 func (g *Generator) Array(ctx context.Context) ([][]byte, error) {
 	err := g.start(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	idsChan := make(chan []byte, bufferSize)
+	array := &idArray{
+		ids:     [][]byte{},
+		idSize:  max(g.IDSize(), g.idLength),
+		idsLeft: g.shardEnd - g.idsGenerated,
+		limit:   g.memoryLimit,
+	}
+	array.grow()
+	err = g.collect(ctx, array.add)
+
+	return array.ids, err
+}
+
+// idArray collects the ids returned by the Array method. The array grows by at least arrayChunkSize ids,
+// or by its capacity once it is larger, but never beyond the ids left to generate. With a memory limit,
+// the capacity of the array is counted against it together with the bytes of the ids, and the array grows
+// only by as many ids as fit in the limit, estimating every id at idSize bytes.
+type idArray struct {
+	ids     [][]byte
+	idSize  int
+	idsLeft int
+	idBytes int
+	limit   int
+}
+
+func (a *idArray) add(id []byte) bool {
+	if len(a.ids) == cap(a.ids) && !a.grow() {
+		return false
+	}
+
+	if a.limit > 0 && a.memory()+len(id) > a.limit {
+		return false
+	}
+
+	a.ids = append(a.ids, id)
+	a.idBytes += len(id)
+	a.idsLeft--
+	return true
+}
+
+func (a *idArray) grow() bool {
+	chunk := min(max(cap(a.ids), arrayChunkSize), a.idsLeft)
+	if a.limit > 0 {
+		chunk = min(chunk, (a.limit-a.memory())/(sliceHeaderSize+a.idSize))
+	}
+
+	if chunk <= 0 {
+		return false
+	}
+
+	grown := make([][]byte, len(a.ids), cap(a.ids)+chunk)
+	copy(grown, a.ids)
+	a.ids = grown
+	return true
+}
+
+func (a *idArray) memory() int {
+	return a.idBytes + cap(a.ids)*sliceHeaderSize
+}
+
+// collect generates the ids in a separate goroutine and passes them to the add function in the calling goroutine.
+// If add reports that the id does not fit in the limit set by WithArrayMemoryLimit, the producer is stopped,
+// the progress is set to the number of ids added and an error wrapping MemoryLimitError is returned.
+func (g *Generator) collect(ctx context.Context, add func(id []byte) bool) error {
+	idsStart := g.idsGenerated
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	idsChan := make(chan []byte, g.bufferSize)
 	go g.streamToChannel(ctx, idsChan)
 
-	results := make([][]byte, 0, g.idsScheduled)
+	var limitErr error
+	idsCollected := 0
 	for id := range idsChan {
-		results = append(results, id)
+		if limitErr != nil {
+			continue
+		}
+
+		if !add(id) {
+			// the producer is stopped and ids generated ahead are discarded
+			limitErr = &MemoryLimitError{Limit: g.memoryLimit}
+			cancel(limitErr)
+			continue
+		}
+
+		idsCollected++
 	}
 
-	return results, g.InterruptionErr()
+	if limitErr != nil {
+		idsGenerated := idsStart + idsCollected
+		g.setIdsGenerated(idsGenerated)
+		g.setInterruptionErr(idsGenerated, limitErr)
+	}
+
+	return g.InterruptionErr()
+}
+
+// MemoryLimitError is returned by the Array method when the ids would exceed the limit set by WithArrayMemoryLimit.
+type MemoryLimitError struct {
+	Limit int
+}
+
+func (e *MemoryLimitError) Error() string {
+	return fmt.Sprintf("array memory limit of %d bytes reached", e.Limit)
 }
 
 // Channel method starts generating the set of ids specified in the Generator constructor
@@ -108,7 +657,7 @@ func (g *Generator) Channel(ctx context.Context) (<-chan []byte, error) {
 		return nil, err
 	}
 
-	idsChan := make(chan []byte, bufferSize)
+	idsChan := make(chan []byte, g.bufferSize)
 	go g.streamToChannel(ctx, idsChan)
 
 	return idsChan, nil
@@ -117,37 +666,46 @@ func (g *Generator) Channel(ctx context.Context) (<-chan []byte, error) {
 func (g *Generator) streamToChannel(ctx context.Context, idsChan chan<- []byte) {
 	defer close(idsChan)
 
-	uniformIndicesGen := internal.NewUniformIndicesGenerator(g.random, len(g.charList))
-	columns := make([]*internal.UniformCharsGenerator, g.idLength)
-	columns[0] = internal.NewUniformCharsGenerator(g.idsScheduled, g.charList, uniformIndicesGen)
+	if g.workers > 1 && !g.filtered() {
+		g.streamPartitionsToChannel(ctx, idsChan)
+		return
+	}
 
-	idsGenerated := 0
-	for idsGenerated < g.idsScheduled {
-		if err := ctx.Err(); err != nil {
-			g.setInterruptionErr(idsGenerated, err)
+	seq := g.newSequence()
+	for id, ok := seq.next(ctx); ok; id, ok = seq.next(ctx) {
+		if !send(ctx, idsChan, id) {
+			seq.cancelLast(ctx.Err())
 			return
 		}
+	}
+}
 
-		id := make([]byte, g.idLength)
-		id[0] = columns[0].Next()
+// send blocks until the id is sent or the context is cancelled, so that the producer is not stuck
+// when the consumer stops reading. Returns false if the id was not sent.
+func send(ctx context.Context, idsChan chan<- []byte, id []byte) bool {
+	select {
+	case idsChan <- id:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
 
-		columnIndex := 1
-		for columnIndex < g.idLength {
-			uniformCharsGen := columns[columnIndex]
-			if uniformCharsGen.Empty() {
-				previousColumnJobSize := columns[columnIndex-1].CurrentJobSize
-				uniformCharsGen = internal.NewUniformCharsGenerator(previousColumnJobSize, g.charList, uniformIndicesGen)
-				columns[columnIndex] = uniformCharsGen
-			}
+// interruption returns the reason to stop generating ids: either the context error or the random source error.
+func (g *Generator) interruption(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
-			id[columnIndex] = uniformCharsGen.Next()
-			columnIndex++
-		}
+	return g.sourceErr()
+}
 
-		g.encoder.Encode(id)
-		idsChan <- id
-		idsGenerated++
+// sourceErr returns the error of the random source, so that ids drawn after reading from it failed are dropped.
+func (g *Generator) sourceErr() error {
+	if g.source != nil {
+		return g.source.Err()
 	}
+	return nil
 }
 
 func (g *Generator) start(ctx context.Context) error {
@@ -1,9 +1,14 @@
 package generateids
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
 	"sync"
 	"testing"
 	"time"
@@ -88,6 +93,95 @@ func TestGenerator_Seed(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("generators return the same results as previous versions", func(t *testing.T) {
+		testCases := []struct {
+			opts         []Option
+			expectedHash string
+		}{
+			{[]Option{WithCount(1000), WithLength(16), WithCharList(charsAlphanumeric)}, "dcdf320b5f6b44f7"},
+			{[]Option{WithCount(1000), WithLength(7), WithCharList(charsAlphanumeric)}, "b4859e9fc0eeac78"},
+			{[]Option{WithCount(2), WithLength(1), WithCharList(charsABC)}, "daee1cd25194ae95"},
+			{[]Option{WithCount(500), WithCharLists([][]byte{charsAB, charsDigits, charsABC, charsDigits, charsAB})}, "2faa65fee603b019"},
+			{[]Option{WithCount(5000), WithLength(9), WithCharList(charsAlphanumeric), WithWorkers(3)}, "09961636f1c7ded2"},
+		}
+
+		for _, tc := range testCases {
+			hash := sha256.New()
+			for _, id := range generateIdsWithOptions(t, append(tc.opts, WithSeed(42))...) {
+				hash.Write(id)
+				hash.Write([]byte{'\n'})
+			}
+
+			if sum := fmt.Sprintf("%x", hash.Sum(nil)[:8]); sum != tc.expectedHash {
+				t.Errorf("expected ids with hash %s, got %s", tc.expectedHash, sum)
+			}
+		}
+	})
+}
+
+func TestGenerator_Shards(t *testing.T) {
+	seed := int64(0)
+	idsToGenerate := 1000
+	idLength := 16
+	expected := generateConcurrentIdsWithSeed(t, idsToGenerate, idLength, charsAlphanumeric, seed, 2)
+
+	for _, totalShards := range []int{1, 3, 7, idsToGenerate} {
+		t.Run(fmt.Sprintf("%d shards form the same set as a single concurrent generator", totalShards), func(t *testing.T) {
+			var results [][]byte
+			for shardIndex := 0; shardIndex < totalShards; shardIndex++ {
+				generator, err := NewShardedGeneratorWithSeed(
+					idsToGenerate, idLength, charsAlphanumeric, seed, shardIndex, totalShards,
+				)
+				if err != nil {
+					t.Fatalf("unexpected constructor error: %s", err)
+				}
+
+				idsArray, err := generator.Array(context.Background())
+				if err != nil {
+					t.Fatalf("unexpected array method error: %s", err)
+				}
+
+				results = append(results, idsArray...)
+			}
+
+			if len(results) != idsToGenerate {
+				t.Fatalf("expected %d results, got %d", idsToGenerate, len(results))
+			}
+
+			for index, id := range expected {
+				if string(id) != string(results[index]) {
+					t.Errorf("expected %s, got %s", id, results[index])
+				}
+			}
+		})
+	}
+
+	t.Run("shards do not reproduce the sequential generator", func(t *testing.T) {
+		generator, err := NewShardedGeneratorWithSeed(idsToGenerate, idLength, charsAlphanumeric, seed, 0, 1)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		idsArray, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		sequential := generateIdsWithSeed(t, idsToGenerate, idLength, charsAlphanumeric, seed)
+		if string(bytes.Join(idsArray, nil)) == string(bytes.Join(sequential, nil)) {
+			t.Errorf("expected shards to differ from the sequential generator with the same seed")
+		}
+	})
+
+	t.Run("returns error when shard is invalid", func(t *testing.T) {
+		for _, shard := range [][2]int{{0, 0}, {-1, 2}, {2, 2}} {
+			_, err := NewShardedGeneratorWithSeed(idsToGenerate, idLength, charsAlphanumeric, seed, shard[0], shard[1])
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for shard %d of %d, got %v", shard[0], shard[1], err)
+			}
+		}
+	})
 }
 
 func generateIdsWithSeed(t *testing.T, idsToGenerate, idLength int, charList []byte, seed int64) [][]byte {
@@ -111,6 +205,106 @@ func generateIdsWithSeed(t *testing.T, idsToGenerate, idLength int, charList []b
 	return idsArray
 }
 
+func TestGenerator_Reader(t *testing.T) {
+	t.Run("secure generator returns only unique IDs", func(t *testing.T) {
+		generator, err := NewSecureGenerator(4096, 12, charsABC)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		uniqueIDs := make(map[string]struct{})
+		for _, id := range results {
+			uniqueIDs[string(id)] = struct{}{}
+		}
+		if len(uniqueIDs) != 4096 {
+			t.Errorf("expected %d unique IDs, got %d", 4096, len(uniqueIDs))
+		}
+	})
+
+	t.Run("generators with the same random bytes return the same results", func(t *testing.T) {
+		var idsArrays [2][][]byte
+		for i := range idsArrays {
+			generator, err := NewGeneratorWithReader(1024, 16, charsAlphanumeric, rand.New(rand.NewSource(0)))
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			idsArrays[i], err = generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+		}
+
+		for index, id := range idsArrays[0] {
+			if string(id) != string(idsArrays[1][index]) {
+				t.Errorf("expected %s, got %s", id, idsArrays[1][index])
+			}
+		}
+	})
+
+	t.Run("returns error when random bytes run out", func(t *testing.T) {
+		_, err := NewGeneratorWithReader(1024, 16, charsAlphanumeric, bytes.NewReader(make([]byte, 64)))
+		if !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			t.Errorf("expected EOF error, got %v", err)
+		}
+	})
+
+	t.Run("stops generating ids when random bytes run out", func(t *testing.T) {
+		randomReader := io.LimitReader(rand.New(rand.NewSource(0)), 5000)
+		generator, err := NewGeneratorWithReader(1<<16, 20, charsAB, randomReader)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if !errors.Is(err, io.EOF) {
+			t.Errorf("expected EOF error, got %v", err)
+		}
+		if len(results) == 0 || len(results) == 1<<16 {
+			t.Errorf("expected partial results, got %d", len(results))
+		}
+
+		generator, err = NewGeneratorWithReader(1<<16, 20, charsAB, rand.New(rand.NewSource(0)))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		// ids drawn after the random bytes ran out are dropped, so the partial results are a prefix of the full run
+		expected, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, expected[:len(results)], results)
+	})
+
+	t.Run("does not support methods replaying the set", func(t *testing.T) {
+		generator, err := NewSecureGenerator(4, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		_, err = generator.At(context.Background(), 0)
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error from at method, got %v", err)
+		}
+
+		_, err = generator.IndexOf(context.Background(), []byte("AB"))
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error from index of method, got %v", err)
+		}
+
+		_, err = generator.Checkpoint()
+		if !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error from checkpoint method, got %v", err)
+		}
+	})
+}
+
 func TestGenerator_Array(t *testing.T) {
 	t.Run("returns no error when unique combinations are possible", func(t *testing.T) {
 		idsToGenerate := 4
@@ -132,6 +326,89 @@ func TestGenerator_Array(t *testing.T) {
 			t.Errorf("expected %d results, got %d", idsToGenerate, len(idsArray))
 		}
 	})
+
+	t.Run("does not reserve memory for the whole set", func(t *testing.T) {
+		generator, err := NewGenerator(math.MaxInt, 128, charsAlphanumeric)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		idsArray, err := generator.Array(ctx)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context error, got %v", err)
+		}
+		if len(idsArray) == 0 {
+			t.Error("expected ids generated before the deadline")
+		}
+	})
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("returns partial result when memory limit is reached with %d workers", workers), func(t *testing.T) {
+			idLength, memoryLimit := 16, 1<<18
+			opts := []Option{
+				WithCount(20_000), WithLength(idLength), WithCharList(charsAlphanumeric), WithSeed(3), WithWorkers(workers),
+			}
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(append(opts, WithArrayMemoryLimit(memoryLimit))...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			idsArray, err := generator.Array(context.Background())
+			var limitErr *MemoryLimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != memoryLimit {
+				t.Fatalf("expected memory limit error, got %v", err)
+			}
+
+			expectedIds := memoryLimit / (idLength + sliceHeaderSize)
+			if len(idsArray) != expectedIds {
+				t.Errorf("expected %d results, got %d", expectedIds, len(idsArray))
+			}
+
+			checkpoint, err := generator.Checkpoint()
+			if err != nil {
+				t.Fatalf("unexpected checkpoint error: %s", err)
+			}
+
+			resumed, err := NewGeneratorFromCheckpoint(checkpoint)
+			if err != nil {
+				t.Fatalf("unexpected checkpoint constructor error: %s", err)
+			}
+
+			rest, err := resumed.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+			assertSameIds(t, expected, append(idsArray, rest...))
+		})
+	}
+
+	t.Run("counts capacity of the array against memory limit", func(t *testing.T) {
+		idLength, memoryLimit := 8, 1000
+		generator, err := New(
+			WithCount(1_000_000), WithLength(idLength), WithCharList(charsAlphanumeric), WithArrayMemoryLimit(memoryLimit),
+		)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		idsArray, err := generator.Array(context.Background())
+		var limitErr *MemoryLimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("expected memory limit error, got %v", err)
+		}
+
+		if memoryUsed := cap(idsArray)*sliceHeaderSize + len(idsArray)*idLength; memoryUsed > memoryLimit {
+			t.Errorf("expected at most %d bytes used, got %d for capacity %d", memoryLimit, memoryUsed, cap(idsArray))
+		}
+		if expectedIds := memoryLimit / (idLength + sliceHeaderSize); len(idsArray) != expectedIds {
+			t.Errorf("expected %d results, got %d", expectedIds, len(idsArray))
+		}
+	})
 }
 
 func TestGenerator_Channel(t *testing.T) {
@@ -283,6 +560,338 @@ func TestGenerator_Context(t *testing.T) {
 	})
 }
 
+func TestGenerator_Decode(t *testing.T) {
+	constructorArgumentSets := []constructorArguments{
+		{8, 3, charsAB},
+		{1024, 10, charsABC},
+		{1024, 127, charsAlphanumeric},
+		{1024, 128, charsAlphanumeric},
+	}
+
+	for _, args := range constructorArgumentSets {
+		runDecodeTest(t, args.idsToGenerate, args.idLength, args.charList)
+	}
+
+	t.Run("returns error when id is invalid", func(t *testing.T) {
+		generator, err := NewGenerator(4, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for _, id := range [][]byte{nil, []byte("A"), []byte("ABA"), []byte("AC")} {
+			_, err = generator.Decode(id)
+			if !errors.Is(err, ErrInvalidID) {
+				t.Errorf("expected invalid id error for %q, got %v", id, err)
+			}
+		}
+	})
+}
+
+func runDecodeTest(t *testing.T, idsToGenerate, idLength int, charList []byte) {
+	testName := fmt.Sprintf("decodes unique IDs for %d idsToGenerate with %d idLength and %d total chars",
+		idsToGenerate, idLength, len(charList),
+	)
+
+	t.Run(testName, func(t *testing.T) {
+		generator, err := NewGenerator(idsToGenerate, idLength, charList)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		uniqueDecodedIDs := make(map[string]struct{})
+		for _, id := range results {
+			decoded, err := generator.Decode(id)
+			if err != nil {
+				t.Fatalf("unexpected decode error: %s", err)
+			}
+
+			_, exists := uniqueDecodedIDs[string(decoded)]
+			if exists {
+				t.Errorf("expected unique decoded IDs, got duplicated %s", decoded)
+			}
+			uniqueDecodedIDs[string(decoded)] = struct{}{}
+
+			generator.encoder.Encode(decoded)
+			if string(decoded) != string(id) {
+				t.Errorf("expected %s after encoding decoded id, got %s", id, decoded)
+			}
+		}
+	})
+}
+
+func TestGenerator_At(t *testing.T) {
+	constructorArgumentSets := []constructorArguments{
+		{8, 3, charsAB},
+		{1024, 10, charsABC},
+		{1000, 128, charsAlphanumeric},
+	}
+
+	for _, args := range constructorArgumentSets {
+		runAtTest(t, args.idsToGenerate, args.idLength, args.charList)
+	}
+
+	t.Run("returns the same IDs as array with other options", func(t *testing.T) {
+		optionSets := [][]Option{
+			{WithCount(9000), WithLength(4), WithCharList(charsAlphanumeric), WithWorkers(2)},
+			{WithCount(30), WithCharLists([][]byte{charsAB, []byte("0123"), charsABC, charsAB})},
+			{WithCount(15), WithLength(3), WithCharList(charsABC), WithBlocklist(Blocklist{Substrings: []string{"AA"}})},
+		}
+
+		for _, opts := range optionSets {
+			generator, err := New(append(opts, WithSeed(3))...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			results, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+
+			for index := 0; index < len(results); index += 1 + index/10 {
+				id, err := generator.At(context.Background(), index)
+				if err != nil {
+					t.Fatalf("unexpected at method error: %s", err)
+				}
+
+				if string(id) != string(results[index]) {
+					t.Errorf("expected %s at %d, got %s", results[index], index, id)
+				}
+			}
+		}
+	})
+
+	t.Run("returns error when index is out of range", func(t *testing.T) {
+		generator, err := NewGenerator(4, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for _, index := range []int{-1, 4} {
+			_, err = generator.At(context.Background(), index)
+			if !errors.Is(err, ErrIndexOutOfRange) {
+				t.Errorf("expected index out of range error for %d, got %v", index, err)
+			}
+		}
+	})
+
+	t.Run("returns context error when given cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		generator, err := NewGenerator(4, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		_, err = generator.At(ctx, 3)
+		if !errors.Is(err, ctx.Err()) {
+			t.Errorf("expected context error, got %v", err)
+		}
+	})
+}
+
+func runAtTest(t *testing.T, idsToGenerate, idLength int, charList []byte) {
+	testName := fmt.Sprintf("returns the same IDs as array for %d idsToGenerate with %d idLength and %d total chars",
+		idsToGenerate, idLength, len(charList),
+	)
+
+	t.Run(testName, func(t *testing.T) {
+		generator, err := NewGenerator(idsToGenerate, idLength, charList)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		for index := 0; index < idsToGenerate; index += 1 + index/10 {
+			id, err := generator.At(context.Background(), index)
+			if err != nil {
+				t.Fatalf("unexpected at method error: %s", err)
+			}
+
+			if string(id) != string(results[index]) {
+				t.Errorf("expected %s at %d, got %s", results[index], index, id)
+			}
+		}
+	})
+}
+
+func TestGenerator_IndexOf(t *testing.T) {
+	t.Run("returns the index of every id in the set", func(t *testing.T) {
+		generator, err := NewGenerator(1000, 11, charsABC)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		for expectedIndex, id := range results {
+			index, err := generator.IndexOf(context.Background(), id)
+			if err != nil {
+				t.Fatalf("unexpected index of method error: %s", err)
+			}
+
+			if index != expectedIndex {
+				t.Errorf("expected index %d for %s, got %d", expectedIndex, id, index)
+			}
+		}
+	})
+
+	t.Run("returns the index of every id in the set with other options", func(t *testing.T) {
+		optionSets := [][]Option{
+			{WithCount(9000), WithLength(4), WithCharList(charsAlphanumeric), WithWorkers(2)},
+			{WithCount(30), WithCharLists([][]byte{charsAB, []byte("0123"), charsABC, charsAB})},
+			{WithCount(15), WithLength(3), WithCharList(charsABC), WithBlocklist(Blocklist{Substrings: []string{"AA"}})},
+		}
+
+		for _, opts := range optionSets {
+			generator, err := New(append(opts, WithSeed(3))...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			results, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+
+			for expectedIndex := 0; expectedIndex < len(results); expectedIndex += 1 + expectedIndex/10 {
+				index, err := generator.IndexOf(context.Background(), results[expectedIndex])
+				if err != nil {
+					t.Fatalf("unexpected index of method error: %s", err)
+				}
+
+				if index != expectedIndex {
+					t.Errorf("expected index %d for %s, got %d", expectedIndex, results[expectedIndex], index)
+				}
+			}
+		}
+	})
+
+	t.Run("returns error when id is not part of the set", func(t *testing.T) {
+		generator, err := NewGenerator(1, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		found := 0
+		for _, id := range [][]byte{[]byte("AA"), []byte("AB"), []byte("BA"), []byte("BB")} {
+			_, err = generator.IndexOf(context.Background(), id)
+			if err == nil {
+				found++
+			} else if !errors.Is(err, ErrIDNotFound) {
+				t.Errorf("expected id not found error for %s, got %v", id, err)
+			}
+		}
+
+		if found != 1 {
+			t.Errorf("expected exactly one id to be found, got %d", found)
+		}
+	})
+
+	t.Run("returns error when id is invalid", func(t *testing.T) {
+		generator, err := NewGenerator(1, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		_, err = generator.IndexOf(context.Background(), []byte("AC"))
+		if !errors.Is(err, ErrInvalidID) {
+			t.Errorf("expected invalid id error, got %v", err)
+		}
+	})
+}
+
+func TestGenerator_Contains(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{"sequential", []Option{WithCount(200), WithLength(5), WithCharList(charsABC)}},
+		{"single column", []Option{WithCount(2), WithLength(1), WithCharList(charsABC)}},
+		{"concurrent", []Option{WithCount(4500), WithLength(3), WithCharList(charsAlphanumeric[:18]), WithWorkers(2)}},
+		{"char lists", []Option{WithCount(30), WithCharLists([][]byte{charsAB, []byte("0123"), charsABC, charsAB})}},
+		{"symbols", []Option{WithCount(20), WithLength(3), WithSymbols(Runes("αβγ"))}},
+		{"template", []Option{WithCount(60), WithTemplate("ID-[A-C]{2}-[0-9]{2}")}},
+		{"check characters", []Option{WithCount(70), WithLength(2), WithCharList(charsDigits), WithCheckCharacters(CheckDamm)}},
+		{"blocklist", []Option{WithCount(15), WithLength(3), WithCharList(charsABC), WithBlocklist(Blocklist{Substrings: []string{"AA"}})}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(tc.opts, WithSeed(5))
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			results, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+
+			expected := make(map[string]struct{}, len(results))
+			for _, id := range results {
+				expected[string(id)] = struct{}{}
+			}
+
+			// a generator with a different seed returns ids outside the set as well
+			candidates := generateIdsWithOptions(t, append(tc.opts, WithSeed(6))...)
+			for _, id := range append(results, candidates...) {
+				contains, err := generator.Contains(context.Background(), id)
+				if err != nil {
+					t.Fatalf("unexpected contains method error: %s", err)
+				}
+
+				if _, ok := expected[string(id)]; ok != contains {
+					t.Fatalf("expected contains to return %t for %s, got %t", ok, id, contains)
+				}
+			}
+		})
+	}
+
+	t.Run("returns false when id is invalid", func(t *testing.T) {
+		generator, err := NewGenerator(1, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for _, id := range [][]byte{[]byte("AC"), []byte("A"), []byte("ABA")} {
+			contains, err := generator.Contains(context.Background(), id)
+			if err != nil || contains {
+				t.Errorf("expected %s not to be contained, got %t, %v", id, contains, err)
+			}
+		}
+	})
+
+	t.Run("returns error when context is cancelled", func(t *testing.T) {
+		generator, err := New(WithCount(100_000), WithLength(4), WithCharList(charsAlphanumeric), WithSeed(5))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = generator.Contains(ctx, []byte("9999"))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context cancelled error, got %v", err)
+		}
+	})
+}
+
 func TestGenerator_OneTimeUse(t *testing.T) {
 	t.Run("can be used only once", func(t *testing.T) {
 		idsToGenerate := 1
@@ -355,20 +964,63 @@ func BenchmarkGenerator(b *testing.B) {
 		{10000, 128, charsAlphanumeric},
 	}
 
-	for _, args := range constructorArgumentSets {
-		runGeneratorBenchmark(b, args.idsToGenerate, args.idLength, args.charList)
+	// 0 workers stands for the sequential generator
+	for _, workers := range []int{0, 1, 2, 4, 8} {
+		for _, args := range constructorArgumentSets {
+			runGeneratorBenchmark(b, args.idsToGenerate, args.idLength, args.charList, workers)
+		}
 	}
 }
 
-func runGeneratorBenchmark(b *testing.B, idsToGenerate, idLength int, charList []byte) {
+func runGeneratorBenchmark(b *testing.B, idsToGenerate, idLength int, charList []byte, workers int) {
 	testName := fmt.Sprintf("generate %d unique IDs with %d length each from %d total chars",
 		idsToGenerate, idLength, len(charList),
 	)
+	if workers > 0 {
+		testName += fmt.Sprintf(" using %d workers", workers)
+	}
 
 	b.Run(testName, func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			generator, _ := NewGenerator(idsToGenerate, idLength, charList)
+			var generator *Generator
+			if workers > 0 {
+				generator, _ = NewConcurrentGenerator(idsToGenerate, idLength, charList, workers)
+			} else {
+				generator, _ = NewGenerator(idsToGenerate, idLength, charList)
+			}
 			_, _ = generator.Array(context.Background())
 		}
 	})
 }
+
+func BenchmarkEncoder(b *testing.B) {
+	constructorArgumentSets := []constructorArguments{
+		{1024, 20, charsAB},
+		{1024, 16, charsAlphanumeric},
+		{1024, 127, charsAlphanumeric},
+		{1024, 16, []byte(AlphabetBase64URL)},
+	}
+
+	for _, args := range constructorArgumentSets {
+		runEncoderBenchmark(b, args.idsToGenerate, args.idLength, args.charList)
+	}
+}
+
+func runEncoderBenchmark(b *testing.B, idsToGenerate, idLength int, charList []byte) {
+	testName := fmt.Sprintf("encode and decode IDs with %d length each from %d total chars", idLength, len(charList))
+
+	b.Run(testName, func(b *testing.B) {
+		generator, _ := NewGeneratorWithSeed(idsToGenerate, idLength, charList, 0)
+		ids, _ := New(
+			WithCount(idsToGenerate), WithLength(idLength), WithCharList(charList), WithSeed(1), WithEncoder(EncoderNone),
+		)
+		columns, _ := ids.Array(context.Background())
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			id := columns[i%len(columns)]
+			generator.encoder.Encode(id)
+			generator.encoder.Decode(id)
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	idStart = 0x00
+	idEnd   = 0x01
+)
+
+var (
+	errBlockedTermInvalid = errors.New("blocked terms must not be empty or contain bytes 0x00 and 0x01")
+	errExcludedInvalid    = errors.New("number of excluded ids must not be negative")
+)
+
+func newFilteredUniquenessError(idsToGenerate, maxToGenerate int) error {
+	return fmt.Errorf(
+		"impossible to generate %d unique IDs without blocked and excluded IDs; maximum of %d unique IDs can be generated",
+		idsToGenerate, maxToGenerate,
+	)
+}
+
+// Blocklist matches ids equal to blocked words or containing blocked substrings, ignoring the case of ASCII letters.
+// Words and substrings are matched by a single automaton, in which words are anchored at both ends of the id
+// with bytes that are not allowed in the terms.
+type Blocklist struct {
+	transitions [][256]int32
+	blocked     []bool
+	start       int32
+}
+
+func ValidateBlocklist(words, substrings []string) error {
+	for _, terms := range [][]string{words, substrings} {
+		for _, term := range terms {
+			if len(term) == 0 || strings.ContainsAny(term, string([]byte{idStart, idEnd})) {
+				return errBlockedTermInvalid
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateFilteredCapacity checks if enough ids are left once blocked and excluded ids are skipped.
+// The number of excluded ids is an upper bound, as excluded ids may be blocked or not belong to the set at all.
+func ValidateFilteredCapacity(idsToGenerate, idsAllowed, idsExcluded int) error {
+	if idsExcluded < 0 {
+		return errExcludedInvalid
+	}
+
+	maxToGenerate := max(idsAllowed-idsExcluded, 0)
+	if idsToGenerate > maxToGenerate {
+		return newFilteredUniquenessError(idsToGenerate, maxToGenerate)
+	}
+	return nil
+}
+
+// FilteredScheduleSize returns the number of ids to schedule so that at least idsToGenerate of them
+// are neither blocked nor excluded, as at most idsBlocked and idsExcluded of the scheduled ids can be skipped.
+// It never exceeds the number of all ids in the set.
+func FilteredScheduleSize(idsToGenerate, idsAllowed, idsBlocked, idsExcluded int) int {
+	return min(add(add(idsToGenerate, idsBlocked), idsExcluded), add(idsAllowed, idsBlocked))
+}
+
+func NewBlocklist(words, substrings []string) *Blocklist {
+	b := &Blocklist{}
+	b.addState()
+
+	for _, word := range words {
+		b.add(append(append([]byte{idStart}, word...), idEnd))
+	}
+	for _, substring := range substrings {
+		b.add([]byte(substring))
+	}
+
+	b.link()
+	b.start = b.transitions[0][idStart]
+
+	return b
+}
+
+func (b *Blocklist) addState() int32 {
+	b.transitions = append(b.transitions, [256]int32{})
+	b.blocked = append(b.blocked, false)
+
+	return int32(len(b.transitions) - 1)
+}
+
+func (b *Blocklist) add(term []byte) {
+	state := int32(0)
+	for _, char := range term {
+		char = foldCase(char)
+		if b.transitions[state][char] == 0 {
+			next := b.addState()
+			b.transitions[state][char] = next
+		}
+		state = b.transitions[state][char]
+	}
+
+	b.blocked[state] = true
+}
+
+// link turns the trie of terms into an automaton, replacing missing transitions with those
+// of the longest suffix present in the trie.
+func (b *Blocklist) link() {
+	fallbacks := make([]int32, len(b.transitions))
+	queue := make([]int32, 0, len(b.transitions))
+
+	for char := 0; char < 256; char++ {
+		if next := b.transitions[0][char]; next != 0 {
+			queue = append(queue, next)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		b.blocked[state] = b.blocked[state] || b.blocked[fallbacks[state]]
+
+		for char := 0; char < 256; char++ {
+			next := b.transitions[state][char]
+			if next == 0 {
+				b.transitions[state][char] = b.transitions[fallbacks[state]][char]
+				continue
+			}
+
+			fallbacks[next] = b.transitions[fallbacks[state]][char]
+			queue = append(queue, next)
+		}
+	}
+}
+
+// Blocked returns true if the rendered id is equal to a blocked word or contains a blocked substring.
+func (b *Blocklist) Blocked(id []byte) bool {
+	state := b.start
+	if b.blocked[state] {
+		return true
+	}
+
+	for _, char := range id {
+		state = b.transitions[state][foldCase(char)]
+		if b.blocked[state] {
+			return true
+		}
+	}
+
+	return b.blocked[b.transitions[state][idEnd]]
+}
+
+// Count returns the numbers of allowed and blocked ids among all ids consisting of the segments.
+// Each segment is a list of alternatives, e.g. characters of a column or a single literal. If the checksum
+// is given, every id is followed by its check characters, rendered as checkAlternatives, and the values
+// of the alternatives of every segment are their indices. Check characters are determined by the rest
+// of the id, so ids are counted by the pair of the states of the automaton and of the checksum.
+// Both numbers are capped at math.MaxInt.
+func (b *Blocklist) Count(segments [][][]byte, checksum *Checksum, checkAlternatives [][]byte) (int, int) {
+	// completions[i] is the number of ways to complete an id after segment i - 1.
+	completions := make([]int, len(segments)+1)
+	completions[len(segments)] = 1
+	for i := len(segments) - 1; i >= 0; i-- {
+		completions[i] = multiply(completions[i+1], len(segments[i]))
+	}
+
+	if b.blocked[b.start] {
+		return 0, Capacity(segments)
+	}
+
+	checkStates, checkState := 1, 0
+	if checksum != nil {
+		checkStates, checkState = checksum.States(), checksum.initialState()
+	}
+
+	// counts[state*checkStates+checkState] is the number of ids reaching both states
+	counts := make([]int, len(b.transitions)*checkStates)
+	nextCounts := make([]int, len(counts))
+	counts[int(b.start)*checkStates+checkState] = 1
+	blocked := 0
+
+	for i, segment := range segments {
+		clear(nextCounts)
+
+		for key, count := range counts {
+			if count == 0 {
+				continue
+			}
+
+			state, checkState := int32(key/checkStates), key%checkStates
+			for value, alternative := range segment {
+				next, isBlocked := b.walk(state, alternative)
+				if isBlocked {
+					blocked = add(blocked, multiply(count, completions[i+1]))
+					continue
+				}
+
+				nextCheckState := 0
+				if checksum != nil {
+					nextCheckState = checksum.nextState(checkState, value, len(segments)-1-i)
+				}
+				nextKey := int(next)*checkStates + nextCheckState
+				nextCounts[nextKey] = add(nextCounts[nextKey], count)
+			}
+		}
+
+		counts, nextCounts = nextCounts, counts
+	}
+
+	allowed := 0
+	for key, count := range counts {
+		if count == 0 {
+			continue
+		}
+
+		state, isBlocked := int32(key/checkStates), false
+		if checksum != nil {
+			for _, value := range checksum.checkValues(key % checkStates) {
+				if state, isBlocked = b.walk(state, checkAlternatives[value]); isBlocked {
+					break
+				}
+			}
+		}
+
+		if isBlocked || b.blocked[b.transitions[state][idEnd]] {
+			blocked = add(blocked, count)
+		} else {
+			allowed = add(allowed, count)
+		}
+	}
+
+	return allowed, blocked
+}
+
+func (b *Blocklist) walk(state int32, alternative []byte) (int32, bool) {
+	for _, char := range alternative {
+		state = b.transitions[state][foldCase(char)]
+		if b.blocked[state] {
+			return state, true
+		}
+	}
+
+	return state, false
+}
+
+// Capacity returns the number of all ids consisting of the segments, capped at math.MaxInt.
+func Capacity(segments [][][]byte) int {
+	ways := 1
+	for _, segment := range segments {
+		ways = multiply(ways, len(segment))
+	}
+
+	return ways
+}
+
+func add(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+func foldCase(char byte) byte {
+	if 'A' <= char && char <= 'Z' {
+		return char + 'a' - 'A'
+	}
+	return char
+}
@@ -11,11 +11,28 @@ type charJob struct {
 type UniformCharsGenerator struct {
 	head           *charJob
 	CurrentJobSize int
+
+	// finished jobs and the occurrences of chars are reused by reset, so that columns generating
+	// many small jobs do not allocate for every id
+	free        *charJob
+	occurrences []int
 }
 
 func NewUniformCharsGenerator(idsToGenerate int, charList []byte, uniformIndicesGen *UniformIndicesGenerator) *UniformCharsGenerator {
+	uniformCharsGen := &UniformCharsGenerator{}
+	uniformCharsGen.reset(idsToGenerate, charList, uniformIndicesGen)
+
+	return uniformCharsGen
+}
+
+// reset makes the empty generator generate the next job of its column, drawing the same indices
+// as NewUniformCharsGenerator.
+func (cg *UniformCharsGenerator) reset(idsToGenerate int, charList []byte, uniformIndicesGen *UniformIndicesGenerator) {
 	totalChars := len(charList)
-	charOccurrencesList := make([]int, totalChars)
+	if cap(cg.occurrences) < totalChars {
+		cg.occurrences = make([]int, totalChars)
+	}
+	charOccurrencesList := cg.occurrences[:totalChars]
 
 	minCharOccurrences := idsToGenerate / totalChars
 	for i := range charList {
@@ -33,18 +50,41 @@ func NewUniformCharsGenerator(idsToGenerate int, charList []byte, uniformIndices
 		}
 	}
 
-	uniformCharsGen := &UniformCharsGenerator{}
 	for charIndex, charOccurrences := range charOccurrencesList {
 		if charOccurrences > 0 {
-			uniformCharsGen.push(&charJob{
-				char:            charList[charIndex],
-				writesFinished:  0,
-				writesScheduled: charOccurrences,
-			})
+			job := cg.newJob()
+			job.char = charList[charIndex]
+			job.writesScheduled = charOccurrences
+			cg.push(job)
 		}
 	}
+}
 
-	return uniformCharsGen
+func (cg *UniformCharsGenerator) newJob() *charJob {
+	if cg.free == nil {
+		return &charJob{}
+	}
+
+	job := cg.free
+	cg.free = job.next
+	*job = charJob{}
+
+	return job
+}
+
+// skipUniformChars draws the same indices as NewUniformCharsGenerator does for fewer ids than chars,
+// without creating it.
+func skipUniformChars(idsToGenerate, totalChars int, uniformIndicesGen *UniformIndicesGenerator) {
+	if totalChars == 1 {
+		return
+	}
+
+	if uniformIndicesGen.generatedAll() {
+		uniformIndicesGen.shuffle()
+	}
+	for i := 0; i < idsToGenerate; i++ {
+		uniformIndicesGen.next()
+	}
 }
 
 func (cg *UniformCharsGenerator) Empty() bool {
@@ -64,14 +104,33 @@ func (cg *UniformCharsGenerator) Next() byte {
 	}
 
 	if cg.head.writesFinished == cg.head.writesScheduled {
-		tmp := cg.head.next
-		cg.head.next = nil
-		cg.head = tmp
+		cg.popJob()
 	}
 
 	return char
 }
 
+func (cg *UniformCharsGenerator) NextJob() (byte, int) {
+	char, jobSize := cg.head.char, cg.head.writesScheduled
+	cg.popJob()
+
+	return char, jobSize
+}
+
+// release moves the jobs left to the free list, so that the generator is empty.
+func (cg *UniformCharsGenerator) release() {
+	for cg.head != nil {
+		cg.popJob()
+	}
+}
+
+func (cg *UniformCharsGenerator) popJob() {
+	tmp := cg.head.next
+	cg.head.next = cg.free
+	cg.free = cg.head
+	cg.head = tmp
+}
+
 func (cg *UniformCharsGenerator) push(job *charJob) {
 	if cg.head == nil {
 		cg.head = job
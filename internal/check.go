@@ -0,0 +1,270 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	CheckNone = iota
+	CheckLuhn
+	CheckDamm
+	CheckISO7064Hybrid
+	CheckISO7064Pure
+)
+
+var (
+	errCheckInvalid     = errors.New("unknown check algorithm")
+	errCheckWithColumns = errors.New("check characters require a single character list")
+	errCheckMismatch    = errors.New("check characters do not match")
+)
+
+func newCheckUnsupportedError(totalChars int) error {
+	return fmt.Errorf("check algorithm does not support %d total chars", totalChars)
+}
+
+// damm10 is the totally anti-symmetric quasigroup of order 10 from the original Damm algorithm.
+var damm10 = [10][10]int{
+	{0, 3, 1, 7, 5, 9, 8, 6, 4, 2},
+	{7, 0, 9, 2, 1, 5, 4, 8, 6, 3},
+	{4, 2, 0, 6, 8, 7, 1, 3, 5, 9},
+	{1, 7, 5, 0, 9, 8, 3, 4, 2, 6},
+	{6, 1, 2, 3, 0, 4, 5, 9, 7, 8},
+	{3, 6, 7, 4, 2, 0, 9, 5, 8, 1},
+	{5, 8, 6, 9, 7, 2, 0, 1, 3, 4},
+	{8, 9, 4, 5, 3, 6, 2, 0, 1, 7},
+	{9, 4, 3, 8, 6, 1, 7, 2, 0, 5},
+	{2, 5, 8, 1, 4, 3, 6, 7, 9, 0},
+}
+
+// isoPureModuli are the moduli of ISO 7064 pure systems with two check characters: MOD 97-10, MOD 661-26
+// and MOD 1271-36, by the number of characters.
+var isoPureModuli = map[int]int{10: 97, 26: 661, 36: 1271}
+
+// irreducible polynomials of GF(2^k) by k, used to build Damm quasigroups of orders divisible by 4.
+var irreducible = [9]int{0, 0, 0b111, 0b1011, 0b10011, 0b100101, 0b1000011, 0b10000011, 0b100011011}
+
+// Checksum computes check characters over the values of characters, i.e. their indices in the character list.
+type Checksum struct {
+	algorithm int
+	charList  []byte
+	values    [256]int
+
+	damm      [][]int
+	dammZeros []int
+}
+
+func ValidateCheck(algorithm int, charList []byte, withColumns bool) error {
+	if algorithm < CheckNone || algorithm > CheckISO7064Pure {
+		return errCheckInvalid
+	}
+
+	if algorithm == CheckNone {
+		return nil
+	}
+
+	if withColumns {
+		return errCheckWithColumns
+	}
+
+	totalChars := len(charList)
+	switch algorithm {
+	case CheckDamm:
+		if totalChars != 10 && (totalChars == 2 || totalChars%4 == 2) {
+			return newCheckUnsupportedError(totalChars)
+		}
+	case CheckISO7064Pure:
+		if _, ok := isoPureModuli[totalChars]; !ok {
+			return newCheckUnsupportedError(totalChars)
+		}
+	}
+	return nil
+}
+
+func NewChecksum(algorithm int, charList []byte) *Checksum {
+	c := &Checksum{
+		algorithm: algorithm,
+		charList:  charList,
+	}
+
+	for i := range c.values {
+		c.values[i] = -1
+	}
+	for i, char := range charList {
+		c.values[char] = i
+	}
+
+	if algorithm == CheckDamm {
+		c.setupDamm()
+	}
+
+	return c
+}
+
+// Length returns the number of check characters.
+func (c *Checksum) Length() int {
+	if c.algorithm == CheckISO7064Pure {
+		return 2
+	}
+	return 1
+}
+
+// Append appends the check characters of the payload.
+func (c *Checksum) Append(payload []byte) []byte {
+	for _, value := range c.compute(payload) {
+		payload = append(payload, c.charList[value])
+	}
+
+	return payload
+}
+
+// Valid returns true if the id consists of characters from the character list
+// and ends with the check characters of the rest of the id.
+func (c *Checksum) Valid(id []byte) bool {
+	if len(id) <= c.Length() {
+		return false
+	}
+
+	for _, char := range id {
+		if c.values[char] < 0 {
+			return false
+		}
+	}
+
+	payloadLength := len(id) - c.Length()
+	for i, value := range c.compute(id[:payloadLength]) {
+		if c.values[id[payloadLength+i]] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Strip returns the id without check characters, or an error if they do not match the rest of the id.
+func (c *Checksum) Strip(id []byte) ([]byte, error) {
+	if !c.Valid(id) {
+		return nil, errCheckMismatch
+	}
+
+	return id[:len(id)-c.Length()], nil
+}
+
+func (c *Checksum) compute(payload []byte) []int {
+	state := c.initialState()
+	for i, char := range payload {
+		state = c.nextState(state, c.values[char], len(payload)-1-i)
+	}
+
+	return c.checkValues(state)
+}
+
+// States returns the number of states of the checksum computed over a payload, which are numbered from 0.
+func (c *Checksum) States() int {
+	totalChars := len(c.charList)
+
+	switch c.algorithm {
+	case CheckISO7064Hybrid:
+		return totalChars + 1
+	case CheckISO7064Pure:
+		return isoPureModuli[totalChars]
+	}
+	return totalChars
+}
+
+func (c *Checksum) initialState() int {
+	if c.algorithm == CheckISO7064Hybrid {
+		return len(c.charList)
+	}
+	return 0
+}
+
+// nextState returns the state of the checksum after the value of the next character of the payload,
+// followed by the given number of characters.
+func (c *Checksum) nextState(state, value, charsLeft int) int {
+	totalChars := len(c.charList)
+
+	switch c.algorithm {
+	case CheckLuhn:
+		// Luhn mod N doubles every second value from the right of the payload
+		addend := value
+		if charsLeft%2 == 0 {
+			addend *= 2
+		}
+		return (state + addend/totalChars + addend%totalChars) % totalChars
+	case CheckDamm:
+		return c.damm[state][value]
+	case CheckISO7064Hybrid:
+		// the hybrid system MOD N+1,N, e.g. MOD 37,36 for 36 characters
+		sum := (state + value) % totalChars
+		if sum == 0 {
+			sum = totalChars
+		}
+		return (2 * sum) % (totalChars + 1)
+	default:
+		// the pure systems with two check characters
+		return ((state + value) * totalChars) % isoPureModuli[totalChars]
+	}
+}
+
+// checkValues returns the values of the check characters of a payload after which the checksum is in the state.
+func (c *Checksum) checkValues(state int) []int {
+	totalChars := len(c.charList)
+
+	switch c.algorithm {
+	case CheckLuhn:
+		return []int{(totalChars - state) % totalChars}
+	case CheckDamm:
+		return []int{c.dammZeros[state]}
+	case CheckISO7064Hybrid:
+		return []int{(totalChars + 1 - state) % totalChars}
+	default:
+		modulus := isoPureModuli[totalChars]
+		remainder := (state * totalChars) % modulus
+		checksum := (modulus + 1 - remainder) % modulus
+		return []int{checksum / totalChars, checksum % totalChars}
+	}
+}
+
+// setupDamm builds a totally anti-symmetric quasigroup of the order equal to the number of characters.
+// Apart from the original table of order 10, it is the direct product of x*y = 2x + y in GF(2^k)
+// and x*y = 2x + y mod m for odd m, where the number of characters is 2^k * m and k is not 1.
+func (c *Checksum) setupDamm() {
+	totalChars := len(c.charList)
+	c.damm = make([][]int, totalChars)
+	c.dammZeros = make([]int, totalChars)
+
+	k, m := 0, totalChars
+	for m%2 == 0 {
+		k++
+		m /= 2
+	}
+
+	for x := 0; x < totalChars; x++ {
+		c.damm[x] = make([]int, totalChars)
+		for y := 0; y < totalChars; y++ {
+			if totalChars == 10 {
+				c.damm[x][y] = damm10[x][y]
+			} else {
+				field := doubleInField(x/m, k) ^ (y / m)
+				ring := (2*(x%m) + y%m) % m
+				c.damm[x][y] = field*m + ring
+			}
+
+			if c.damm[x][y] == 0 {
+				c.dammZeros[x] = y
+			}
+		}
+	}
+}
+
+func doubleInField(x, k int) int {
+	if k == 0 {
+		return 0
+	}
+
+	x <<= 1
+	if x >= 1<<k {
+		x ^= irreducible[k]
+	}
+	return x
+}
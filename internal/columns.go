@@ -0,0 +1,211 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+)
+
+type ColumnsGenerator struct {
+	random             *rand.Rand
+	charLists          [][]byte
+	uniformIndicesGens []*UniformIndicesGenerator
+	columns            []*UniformCharsGenerator
+}
+
+// NewColumnsGenerator creates the column schedule of ids, with one character list per column.
+// Columns with character lists of the same size share the generator of uniform indices.
+func NewColumnsGenerator(random *rand.Rand, idsToGenerate int, charLists [][]byte) *ColumnsGenerator {
+	uniformIndicesGens := newUniformIndicesGenerators(random, charLists)
+	columns := make([]*UniformCharsGenerator, len(charLists))
+	columns[0] = NewUniformCharsGenerator(idsToGenerate, charLists[0], uniformIndicesGens[0])
+
+	return &ColumnsGenerator{
+		random:             random,
+		charLists:          charLists,
+		uniformIndicesGens: uniformIndicesGens,
+		columns:            columns,
+	}
+}
+
+func newUniformIndicesGenerators(random *rand.Rand, charLists [][]byte) []*UniformIndicesGenerator {
+	uniformIndicesGens := make([]*UniformIndicesGenerator, len(charLists))
+	bySize := make(map[int]*UniformIndicesGenerator, 1)
+
+	for i, charList := range charLists {
+		uniformIndicesGen, ok := bySize[len(charList)]
+		if !ok {
+			uniformIndicesGen = NewUniformIndicesGenerator(random, len(charList))
+			bySize[len(charList)] = uniformIndicesGen
+		}
+		uniformIndicesGens[i] = uniformIndicesGen
+	}
+
+	return uniformIndicesGens
+}
+
+// reset makes the generator schedule the given number of ids anew, drawing the same values from its random number
+// generator as NewColumnsGenerator, while reusing the memory of its columns.
+func (cg *ColumnsGenerator) reset(idsToGenerate int) {
+	for i, uniformIndicesGen := range cg.uniformIndicesGens {
+		if !containsGenerator(cg.uniformIndicesGens[:i], uniformIndicesGen) {
+			uniformIndicesGen.reset()
+		}
+	}
+
+	for _, uniformCharsGen := range cg.columns {
+		if uniformCharsGen != nil {
+			uniformCharsGen.release()
+		}
+	}
+	cg.columns[0].reset(idsToGenerate, cg.charLists[0], cg.uniformIndicesGens[0])
+}
+
+type Schedule interface {
+	Next(id []byte)
+	Skip(idsToSkip int)
+}
+
+func (cg *ColumnsGenerator) Next(id []byte) {
+	id[0] = cg.columns[0].Next()
+
+	columnIndex := 1
+	for columnIndex < len(cg.columns) {
+		uniformCharsGen := cg.columns[columnIndex]
+		if uniformCharsGen.Empty() {
+			uniformCharsGen = cg.resetColumn(columnIndex, cg.columns[columnIndex-1].CurrentJobSize)
+		}
+
+		id[columnIndex] = uniformCharsGen.Next()
+		columnIndex++
+	}
+}
+
+// resetColumn makes the empty generator of the column generate the jobs of a job of the previous column,
+// creating the generator on first use.
+func (cg *ColumnsGenerator) resetColumn(columnIndex, jobSize int) *UniformCharsGenerator {
+	uniformCharsGen := cg.columns[columnIndex]
+	if uniformCharsGen == nil {
+		uniformCharsGen = &UniformCharsGenerator{}
+		cg.columns[columnIndex] = uniformCharsGen
+	}
+
+	uniformCharsGen.reset(jobSize, cg.charLists[columnIndex], cg.uniformIndicesGens[columnIndex])
+	return uniformCharsGen
+}
+
+func (cg *ColumnsGenerator) Skip(idsToSkip int) {
+	id := make([]byte, len(cg.columns))
+	for i := 0; i < idsToSkip; i++ {
+		cg.Next(id)
+	}
+}
+
+// At takes the id at the given index of the schedule into the given buffer. Instead of generating the ids
+// preceding it, only the character jobs of their columns are drawn, so the ColumnsGenerator must be newly created
+// and cannot be used afterwards.
+func (cg *ColumnsGenerator) At(ctx context.Context, index int, id []byte) error {
+	uniformCharsGen := cg.columns[0]
+	for columnIndex := range cg.charLists {
+		for {
+			char, jobSize := uniformCharsGen.NextJob()
+			if index < jobSize {
+				id[columnIndex] = char
+				if columnIndex+1 < len(cg.charLists) {
+					uniformCharsGen = cg.resetColumn(columnIndex+1, jobSize)
+				}
+				break
+			}
+
+			index -= jobSize
+			if err := cg.skipJob(ctx, columnIndex+1, jobSize); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// IndexOf returns the index of the id in the column form in the schedule, or false if the id is not part of it.
+// Instead of generating the ids preceding it, only the character jobs of their columns are drawn and the sizes
+// of the skipped jobs are summed up, so the ColumnsGenerator must be newly created and cannot be used afterwards.
+func (cg *ColumnsGenerator) IndexOf(ctx context.Context, id []byte) (int, bool, error) {
+	index := 0
+	uniformCharsGen := cg.columns[0]
+	for columnIndex, charList := range cg.charLists {
+		target := bytes.IndexByte(charList, id[columnIndex])
+
+		// jobs are ordered by the indices of their chars, so the jobs preceding the target are skipped entirely
+		for {
+			if uniformCharsGen.Empty() {
+				return 0, false, nil
+			}
+
+			char, jobSize := uniformCharsGen.NextJob()
+			charIndex := bytes.IndexByte(charList, char)
+			if charIndex > target {
+				return 0, false, nil
+			}
+
+			if charIndex == target {
+				if columnIndex+1 < len(cg.charLists) {
+					uniformCharsGen = cg.resetColumn(columnIndex+1, jobSize)
+				}
+				break
+			}
+
+			index += jobSize
+			if err := cg.skipJob(ctx, columnIndex+1, jobSize); err != nil {
+				return 0, false, err
+			}
+		}
+	}
+
+	return index, true, nil
+}
+
+// skipJob draws the character jobs of the columns starting at columnIndex for a job of the previous column,
+// in the same order as the Next method does.
+func (cg *ColumnsGenerator) skipJob(ctx context.Context, columnIndex, jobSize int) error {
+	if columnIndex == len(cg.charLists) {
+		return nil
+	}
+
+	if jobSize == 1 {
+		cg.skipSingleIds(columnIndex, 1)
+		return nil
+	}
+
+	// every char is drawn at most once, so the jobs of the column do not have to be listed
+	if totalChars := len(cg.charLists[columnIndex]); jobSize < totalChars {
+		skipUniformChars(jobSize, totalChars, cg.uniformIndicesGens[columnIndex])
+		cg.skipSingleIds(columnIndex+1, jobSize)
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// the generators of the following columns are not in use while the job is skipped, so they are reused
+	uniformCharsGen := cg.resetColumn(columnIndex, jobSize)
+	for !uniformCharsGen.Empty() {
+		_, nextJobSize := uniformCharsGen.NextJob()
+		if err := cg.skipJob(ctx, columnIndex+1, nextJobSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipSingleIds draws the character jobs of the columns starting at columnIndex for the given number of jobs
+// of the previous column, each of a single id.
+func (cg *ColumnsGenerator) skipSingleIds(columnIndex, ids int) {
+	for ; ids > 0; ids-- {
+		for i := columnIndex; i < len(cg.charLists); i++ {
+			skipUniformChars(1, len(cg.charLists[i]), cg.uniformIndicesGens[i])
+		}
+	}
+}
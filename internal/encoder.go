@@ -4,13 +4,25 @@ import (
 	"math/rand"
 )
 
+type Encoder interface {
+	Encode(id []byte)
+	Decode(id []byte)
+}
+
+type NoopEncoder struct{}
+
+func (NoopEncoder) Encode([]byte) {}
+
+func (NoopEncoder) Decode([]byte) {}
+
 type SymmetricEncoder struct {
-	end           int
-	pairEncodings map[pair]pair
+	end        int
+	pairTables []*pairTable
 
 	odd             bool
 	mid             int
-	singleEncodings map[byte]byte
+	singleEncodings [256]byte
+	singleDecodings [256]byte
 }
 
 type pair struct {
@@ -18,40 +30,96 @@ type pair struct {
 	c2 byte
 }
 
-func NewSymmetricEncoder(random *rand.Rand, idLength int, charList []byte) *SymmetricEncoder {
+// pairTable permutes pairs of characters from two character lists. Pairs are indexed densely by the indices
+// of their characters, so that encoding a pair takes two array lookups instead of a map lookup.
+type pairTable struct {
+	indices1   [256]int32
+	indices2   [256]int32
+	totalChars int32
+	encodings  []pair
+	decodings  []pair
+}
+
+func (t *pairTable) index(c1, c2 byte) int32 {
+	return t.indices1[c1]*t.totalChars + t.indices2[c2]
+}
+
+// NewSymmetricEncoder creates an encoder permuting pairs of characters placed symmetrically around the middle
+// of the id, and the middle character itself. Characters stay in the character lists of their columns,
+// so columns with different character lists can be encoded as well. Columns with the same pair
+// of character lists share the permutation.
+func NewSymmetricEncoder(random *rand.Rand, charLists [][]byte) *SymmetricEncoder {
 	e := &SymmetricEncoder{}
+	idLength := len(charLists)
 
-	e.setupPairEncodings(random, idLength, charList)
+	e.setupPairEncodings(random, charLists)
 	if e.odd = idLength%2 == 1; e.odd {
-		e.setupMidEncoding(random, idLength, charList)
+		e.setupMidEncoding(random, idLength, charLists[idLength/2])
 	}
 
 	return e
 }
 
-func (e *SymmetricEncoder) setupPairEncodings(random *rand.Rand, idLength int, charList []byte) {
-	totalChars := len(charList)
+func (e *SymmetricEncoder) setupPairEncodings(random *rand.Rand, charLists [][]byte) {
+	type charListsPair struct {
+		charList1 string
+		charList2 string
+	}
+
+	tablesByCharLists := make(map[charListsPair]*pairTable, 1)
+	idLength := len(charLists)
+
+	for i, j := 0, idLength-1; i < j; i, j = i+1, j-1 {
+		key := charListsPair{string(charLists[i]), string(charLists[j])}
+		table, ok := tablesByCharLists[key]
+		if !ok {
+			table = newPairTable(random, charLists[i], charLists[j])
+			tablesByCharLists[key] = table
+		}
+
+		e.pairTables = append(e.pairTables, table)
+	}
+
+	if idLength == 1 {
+		// The permutation is never used, but drawing it keeps the random state, and so the ids, unchanged
+		// from the versions that always drew one.
+		newPairTable(random, charLists[0], charLists[0])
+	}
+
+	e.end = idLength - 1
+}
+
+func newPairTable(random *rand.Rand, charList1, charList2 []byte) *pairTable {
+	totalPairs := len(charList1) * len(charList2)
+	t := &pairTable{
+		totalChars: int32(len(charList2)),
+		encodings:  make([]pair, 0, totalPairs),
+		decodings:  make([]pair, totalPairs),
+	}
+
+	for i, c := range charList1 {
+		t.indices1[c] = int32(i)
+	}
+	for i, c := range charList2 {
+		t.indices2[c] = int32(i)
+	}
 
-	pairs := make([]pair, 0, totalChars*totalChars)
-	for i := 0; i < totalChars; i++ {
-		for j := 0; j < totalChars; j++ {
-			pairs = append(pairs, pair{charList[i], charList[j]})
+	// pairs are listed in the order of their indices, so the encodings start as the identity permutation
+	for i := 0; i < len(charList1); i++ {
+		for j := 0; j < len(charList2); j++ {
+			t.encodings = append(t.encodings, pair{charList1[i], charList2[j]})
 		}
 	}
 
-	shuffledPairs := make([]pair, totalChars*totalChars)
-	copy(shuffledPairs, pairs)
-	random.Shuffle(totalChars*totalChars, func(i, j int) {
-		shuffledPairs[i], shuffledPairs[j] = shuffledPairs[j], shuffledPairs[i]
+	random.Shuffle(totalPairs, func(i, j int) {
+		t.encodings[i], t.encodings[j] = t.encodings[j], t.encodings[i]
 	})
 
-	pairEncodings := make(map[pair]pair, totalChars*totalChars)
-	for i, p := range pairs {
-		pairEncodings[p] = shuffledPairs[i]
+	for i, p := range t.encodings {
+		t.decodings[t.index(p.c1, p.c2)] = pair{charList1[i/len(charList2)], charList2[i%len(charList2)]}
 	}
 
-	e.end = idLength - 1
-	e.pairEncodings = pairEncodings
+	return t
 }
 
 func (e *SymmetricEncoder) setupMidEncoding(random *rand.Rand, idLength int, charList []byte) {
@@ -63,20 +131,20 @@ func (e *SymmetricEncoder) setupMidEncoding(random *rand.Rand, idLength int, cha
 		shuffledChars[i], shuffledChars[j] = shuffledChars[j], shuffledChars[i]
 	})
 
-	singleEncodings := make(map[byte]byte, totalChars)
 	for i, c := range charList {
-		singleEncodings[c] = shuffledChars[i]
+		e.singleEncodings[c] = shuffledChars[i]
+		e.singleDecodings[shuffledChars[i]] = c
 	}
 
 	e.odd = true
 	e.mid = idLength / 2
-	e.singleEncodings = singleEncodings
 }
 
 func (e *SymmetricEncoder) Encode(id []byte) {
 	i, j := 0, e.end
 	for i < j {
-		encoding := e.pairEncodings[pair{id[i], id[j]}]
+		table := e.pairTables[i]
+		encoding := table.encodings[table.index(id[i], id[j])]
 		id[i] = encoding.c1
 		id[j] = encoding.c2
 
@@ -88,3 +156,20 @@ func (e *SymmetricEncoder) Encode(id []byte) {
 		id[e.mid] = e.singleEncodings[id[e.mid]]
 	}
 }
+
+func (e *SymmetricEncoder) Decode(id []byte) {
+	i, j := 0, e.end
+	for i < j {
+		table := e.pairTables[i]
+		decoding := table.decodings[table.index(id[i], id[j])]
+		id[i] = decoding.c1
+		id[j] = decoding.c2
+
+		i++
+		j--
+	}
+
+	if e.odd {
+		id[e.mid] = e.singleDecodings[id[e.mid]]
+	}
+}
@@ -29,6 +29,15 @@ func NewUniformIndicesGenerator(random *rand.Rand, total int) *UniformIndicesGen
 	return ig
 }
 
+// reset shuffles the indices as NewUniformIndicesGenerator does.
+func (ig *UniformIndicesGenerator) reset() {
+	for i := range ig.indices {
+		ig.indices[i] = i
+	}
+	ig.current = 0
+	ig.shuffle()
+}
+
 func (ig *UniformIndicesGenerator) generatedAll() bool {
 	return ig.generated >= ig.length
 }
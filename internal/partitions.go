@@ -0,0 +1,232 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+)
+
+const maxPartitionSize = 4096
+
+type Partition struct {
+	Prefix []byte
+	Size   int
+
+	seed         int64
+	charLists    [][]byte
+	columnsGen   *ColumnsGenerator
+	started      bool
+	idsGenerated int
+}
+
+func (p *Partition) Next(id []byte) {
+	copy(id, p.Prefix)
+
+	if !p.started {
+		p.start()
+	}
+
+	p.idsGenerated++
+	p.columnsGen.Next(id[len(p.Prefix):])
+}
+
+// start sets up the columns generator of the partition and advances it past the skipped ids. The generator
+// left by a previous partition is reseeded and reused, so that generating partitions one after another
+// does not allocate for every partition.
+func (p *Partition) start() {
+	p.started = true
+	if p.columnsGen == nil {
+		p.columnsGen = p.newColumnsGenerator()
+	} else {
+		p.columnsGen.random.Seed(p.seed)
+		p.columnsGen.reset(p.Size)
+	}
+
+	if p.idsGenerated > 0 {
+		p.columnsGen.Skip(p.idsGenerated)
+	}
+}
+
+func (p *Partition) newColumnsGenerator() *ColumnsGenerator {
+	random := rand.New(rand.NewSource(p.seed))
+	return NewColumnsGenerator(random, p.Size, p.charLists[len(p.Prefix):])
+}
+
+// Skip skips the ids of the partition. Until the first id is generated, skipped ids are only counted,
+// so that skipping a whole partition does not draw its columns.
+func (p *Partition) Skip(idsToSkip int) {
+	p.idsGenerated += idsToSkip
+	if p.started {
+		p.columnsGen.Skip(idsToSkip)
+	}
+}
+
+type PartitionsGenerator struct {
+	random             *rand.Rand
+	charLists          [][]byte
+	uniformIndicesGens []*UniformIndicesGenerator
+	levels             []*UniformCharsGenerator
+	prefix             []byte
+	idsLeft            int
+}
+
+func NewPartitionsGenerator(random *rand.Rand, idsToGenerate int, charLists [][]byte) *PartitionsGenerator {
+	depth, partitionSize := 0, idsToGenerate
+	for partitionSize > maxPartitionSize && depth < len(charLists) {
+		totalChars := len(charLists[depth])
+		partitionSize = (partitionSize + totalChars - 1) / totalChars
+		depth++
+	}
+
+	pg := &PartitionsGenerator{
+		random:    random,
+		charLists: charLists,
+		levels:    make([]*UniformCharsGenerator, depth),
+		prefix:    make([]byte, depth),
+		idsLeft:   idsToGenerate,
+	}
+
+	if depth > 0 {
+		pg.uniformIndicesGens = newUniformIndicesGenerators(random, charLists[:depth])
+		pg.levels[0] = NewUniformCharsGenerator(idsToGenerate, charLists[0], pg.uniformIndicesGens[0])
+	}
+
+	return pg
+}
+
+func (pg *PartitionsGenerator) Empty() bool {
+	return pg.idsLeft == 0
+}
+
+func (pg *PartitionsGenerator) Next() *Partition {
+	partition := &Partition{}
+	pg.next(partition)
+
+	return partition
+}
+
+// next makes the partition the next one, keeping the memory of its prefix and columns generator.
+func (pg *PartitionsGenerator) next(partition *Partition) {
+	size := pg.idsLeft
+	if len(pg.levels) > 0 {
+		size = pg.nextPrefix()
+	}
+	pg.idsLeft -= size
+
+	partition.Prefix = append(partition.Prefix[:0], pg.prefix...)
+	partition.Size = size
+	partition.seed = pg.random.Int63()
+	partition.charLists = pg.charLists
+	partition.started = false
+	partition.idsGenerated = 0
+}
+
+func (pg *PartitionsGenerator) nextPrefix() int {
+	level := len(pg.levels) - 1
+	for pg.levels[level].Empty() {
+		level--
+	}
+
+	for {
+		char, jobSize := pg.levels[level].NextJob()
+		pg.prefix[level] = char
+		if level == len(pg.levels)-1 {
+			return jobSize
+		}
+
+		level++
+		if pg.levels[level] == nil {
+			pg.levels[level] = NewUniformCharsGenerator(jobSize, pg.charLists[level], pg.uniformIndicesGens[level])
+		} else {
+			pg.levels[level].reset(jobSize, pg.charLists[level], pg.uniformIndicesGens[level])
+		}
+	}
+}
+
+type PartitionedColumnsGenerator struct {
+	partitionsGen *PartitionsGenerator
+	partition     *Partition
+	idsLeft       int
+}
+
+func NewPartitionedColumnsGenerator(random *rand.Rand, idsToGenerate int, charLists [][]byte) *PartitionedColumnsGenerator {
+	return &PartitionedColumnsGenerator{
+		partitionsGen: NewPartitionsGenerator(random, idsToGenerate, charLists),
+		partition:     &Partition{},
+	}
+}
+
+func (pcg *PartitionedColumnsGenerator) Next(id []byte) {
+	if pcg.idsLeft == 0 {
+		pcg.partitionsGen.next(pcg.partition)
+		pcg.idsLeft = pcg.partition.Size
+	}
+
+	pcg.partition.Next(id)
+	pcg.idsLeft--
+}
+
+func (pcg *PartitionedColumnsGenerator) Skip(idsToSkip int) {
+	for idsToSkip > 0 {
+		if pcg.idsLeft == 0 {
+			pcg.partition = pcg.partitionsGen.Next()
+			pcg.idsLeft = pcg.partition.Size
+		}
+
+		partitionSkip := min(idsToSkip, pcg.idsLeft)
+		pcg.partition.Skip(partitionSkip)
+		pcg.idsLeft -= partitionSkip
+		idsToSkip -= partitionSkip
+	}
+}
+
+// IndexOf returns the index of the id in the column form in the schedule, or false if the id is not part of it.
+// Only the partition with the prefix of the id is searched, and the sizes of the preceding partitions are summed up,
+// so the PartitionedColumnsGenerator must be newly created and cannot be used afterwards.
+func (pcg *PartitionedColumnsGenerator) IndexOf(ctx context.Context, id []byte) (int, bool, error) {
+	index := 0
+	for !pcg.partitionsGen.Empty() {
+		if err := ctx.Err(); err != nil {
+			return 0, false, err
+		}
+
+		partition := pcg.partitionsGen.Next()
+		if !bytes.Equal(partition.Prefix, id[:len(partition.Prefix)]) {
+			index += partition.Size
+			continue
+		}
+
+		if len(partition.Prefix) == len(id) {
+			return index, true, nil
+		}
+
+		partitionIndex, ok, err := partition.newColumnsGenerator().IndexOf(ctx, id[len(partition.Prefix):])
+		return index + partitionIndex, ok, err
+	}
+
+	return 0, false, nil
+}
+
+// At takes the id at the given index of the schedule into the given buffer. Partitions preceding the id
+// are skipped without drawing their columns, so the PartitionedColumnsGenerator must be newly created
+// and cannot be used afterwards.
+func (pcg *PartitionedColumnsGenerator) At(ctx context.Context, index int, id []byte) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		partition := pcg.partitionsGen.Next()
+		if index >= partition.Size {
+			index -= partition.Size
+			continue
+		}
+
+		copy(id, partition.Prefix)
+		if len(partition.Prefix) == len(id) {
+			return nil
+		}
+
+		return partition.newColumnsGenerator().At(ctx, index, id[len(partition.Prefix):])
+	}
+}
@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"sync"
+)
+
+const readerBufferSize = 4096
+
+type ReaderSource struct {
+	reader   *bufio.Reader
+	buf      [8]byte
+	err      error
+	fallback rand.Source64
+	mu       sync.Mutex
+}
+
+func NewReaderSource(reader io.Reader) *ReaderSource {
+	return &ReaderSource{
+		reader:   bufio.NewReaderSize(reader, readerBufferSize),
+		fallback: rand.NewSource(0).(rand.Source64),
+	}
+}
+
+func (s *ReaderSource) Int63() int64 {
+	return int64(s.Uint64() & (1<<63 - 1))
+}
+
+func (s *ReaderSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// after a read error, values are still needed to finish the current shuffle, as constant values
+	// would never pass rejection sampling
+	if s.err != nil {
+		return s.fallback.Uint64()
+	}
+
+	_, err := io.ReadFull(s.reader, s.buf[:])
+	if err != nil {
+		s.err = err
+		return s.fallback.Uint64()
+	}
+
+	return binary.LittleEndian.Uint64(s.buf[:])
+}
+
+func (s *ReaderSource) Seed(int64) {}
+
+func (s *ReaderSource) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+// CountingSource is a seeded source which counts the values drawn from it, so that its state can be restored
+// by drawing the same number of values from a source with the same seed.
+type CountingSource struct {
+	source rand.Source64
+	draws  uint64
+}
+
+func NewCountingSource(seed int64) *CountingSource {
+	return &CountingSource{source: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *CountingSource) Int63() int64 {
+	s.draws++
+	return s.source.Int63()
+}
+
+func (s *CountingSource) Uint64() uint64 {
+	s.draws++
+	return s.source.Uint64()
+}
+
+func (s *CountingSource) Seed(seed int64) {
+	s.source.Seed(seed)
+	s.draws = 0
+}
+
+func (s *CountingSource) Draws() uint64 {
+	return s.draws
+}
+
+// Skip draws the given number of values.
+func (s *CountingSource) Skip(draws uint64) {
+	for i := uint64(0); i < draws; i++ {
+		s.source.Uint64()
+	}
+	s.draws += draws
+}
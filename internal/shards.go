@@ -0,0 +1,14 @@
+package internal
+
+func ShardRange(idsToGenerate, shardIndex, totalShards int) (int, int) {
+	minShardSize := idsToGenerate / totalShards
+	largerShards := idsToGenerate % totalShards
+
+	start := shardIndex*minShardSize + min(shardIndex, largerShards)
+	end := start + minShardSize
+	if shardIndex < largerShards {
+		end++
+	}
+
+	return start, end
+}
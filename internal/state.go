@@ -0,0 +1,216 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var errStateInvalid = errors.New("invalid schedule state")
+
+// AppendState appends the state of the column schedule to data: the state of the generators of uniform indices
+// and the character jobs left in every column. Together with the state of the random number generator,
+// it determines the rest of the schedule.
+func (cg *ColumnsGenerator) AppendState(data []byte) []byte {
+	for _, uniformIndicesGen := range cg.distinctUniformIndicesGens() {
+		data = binary.AppendUvarint(data, uint64(uniformIndicesGen.current))
+		data = binary.AppendUvarint(data, uint64(uniformIndicesGen.generated))
+		for _, index := range uniformIndicesGen.indices {
+			data = append(data, byte(index))
+		}
+	}
+
+	for _, uniformCharsGen := range cg.columns {
+		jobs := 0
+		for job := uniformCharsGen.first(); job != nil; job = job.next {
+			jobs++
+		}
+
+		data = binary.AppendUvarint(data, uint64(jobs))
+		for job := uniformCharsGen.first(); job != nil; job = job.next {
+			data = append(data, job.char)
+			data = binary.AppendUvarint(data, uint64(job.writesFinished))
+			data = binary.AppendUvarint(data, uint64(job.writesScheduled))
+		}
+	}
+
+	return data
+}
+
+// RestoreState sets the state appended by AppendState on a newly created ColumnsGenerator with the same
+// character lists. The state is checked to be consistent, so that generating ids from it cannot fail.
+func (cg *ColumnsGenerator) RestoreState(data []byte) error {
+	r := &stateReader{data: data}
+	for _, uniformIndicesGen := range cg.distinctUniformIndicesGens() {
+		current, generated := r.readInt(), r.readInt()
+		indices := r.readBytes(uniformIndicesGen.length)
+		if r.err != nil {
+			return r.err
+		}
+
+		if current >= uniformIndicesGen.length || !isPermutation(indices) {
+			return errStateInvalid
+		}
+
+		uniformIndicesGen.current = current
+		uniformIndicesGen.generated = generated
+		for i, index := range indices {
+			uniformIndicesGen.indices[i] = int(index)
+		}
+	}
+
+	for columnIndex, charList := range cg.charLists {
+		uniformCharsGen, err := r.readColumn(charList)
+		if err != nil {
+			return err
+		}
+
+		// a column generates the ids of the current job of the previous column, so the numbers of ids left must match
+		if columnIndex > 0 && uniformCharsGen.idsLeft() != cg.columns[columnIndex-1].idsLeftInJob() {
+			return errStateInvalid
+		}
+		cg.columns[columnIndex] = uniformCharsGen
+	}
+
+	if len(r.data) > 0 {
+		return errStateInvalid
+	}
+	return nil
+}
+
+// IdsLeft returns the number of ids left in the schedule.
+func (cg *ColumnsGenerator) IdsLeft() int {
+	return cg.columns[0].idsLeft()
+}
+
+func (cg *ColumnsGenerator) distinctUniformIndicesGens() []*UniformIndicesGenerator {
+	var distinct []*UniformIndicesGenerator
+	for _, uniformIndicesGen := range cg.uniformIndicesGens {
+		if !containsGenerator(distinct, uniformIndicesGen) {
+			distinct = append(distinct, uniformIndicesGen)
+		}
+	}
+
+	return distinct
+}
+
+func containsGenerator(uniformIndicesGens []*UniformIndicesGenerator, uniformIndicesGen *UniformIndicesGenerator) bool {
+	for _, g := range uniformIndicesGens {
+		if g == uniformIndicesGen {
+			return true
+		}
+	}
+	return false
+}
+
+func isPermutation(indices []byte) bool {
+	seen := make([]bool, len(indices))
+	for _, index := range indices {
+		if int(index) >= len(indices) || seen[index] {
+			return false
+		}
+		seen[index] = true
+	}
+	return true
+}
+
+func (cg *UniformCharsGenerator) first() *charJob {
+	if cg == nil {
+		return nil
+	}
+
+	return cg.head
+}
+
+func (cg *UniformCharsGenerator) idsLeft() int {
+	idsLeft := 0
+	for job := cg.first(); job != nil; job = job.next {
+		idsLeft += job.writesScheduled - job.writesFinished
+	}
+
+	return idsLeft
+}
+
+// idsLeftInJob returns the number of ids left in the job being generated, or 0 if the next job is not started yet.
+func (cg *UniformCharsGenerator) idsLeftInJob() int {
+	job := cg.first()
+	if job == nil || job.writesFinished == 0 {
+		return 0
+	}
+
+	return job.writesScheduled - job.writesFinished
+}
+
+type stateReader struct {
+	data []byte
+	err  error
+}
+
+// readColumn reads the character jobs left in a column. Jobs are ordered by the indices of their chars,
+// and only the first one can be started.
+func (r *stateReader) readColumn(charList []byte) (*UniformCharsGenerator, error) {
+	uniformCharsGen := &UniformCharsGenerator{}
+	jobs := r.readInt()
+	if jobs > len(charList) {
+		return nil, errStateInvalid
+	}
+
+	previousCharIndex := -1
+	for i := 0; i < jobs; i++ {
+		chars := r.readBytes(1)
+		writesFinished, writesScheduled := r.readInt(), r.readInt()
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		charIndex := bytes.IndexByte(charList, chars[0])
+		if charIndex <= previousCharIndex || writesFinished >= writesScheduled || (i > 0 && writesFinished > 0) {
+			return nil, errStateInvalid
+		}
+		previousCharIndex = charIndex
+
+		job := uniformCharsGen.newJob()
+		job.char = chars[0]
+		job.writesFinished = writesFinished
+		job.writesScheduled = writesScheduled
+		uniformCharsGen.push(job)
+
+		if writesFinished > 0 {
+			uniformCharsGen.CurrentJobSize = writesScheduled
+		}
+	}
+
+	return uniformCharsGen, r.err
+}
+
+func (r *stateReader) readInt() int {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 || value > math.MaxInt {
+		r.err = errStateInvalid
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return int(value)
+}
+
+func (r *stateReader) readBytes(length int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if len(r.data) < length {
+		r.err = errStateInvalid
+		return nil
+	}
+
+	value := r.data[:length]
+	r.data = r.data[length:]
+
+	return value
+}
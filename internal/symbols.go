@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const maxSymbols = 256
+
+var (
+	errSymbolsTooMany   = fmt.Errorf("%w: more than %d symbols", errCharListInvalid, maxSymbols)
+	errSymbolEmpty      = fmt.Errorf("%w: empty symbol", errCharListInvalid)
+	errSymbolsWithChars = errors.New("symbols and character list cannot be used together")
+)
+
+func newSymbolInvalidUTF8Error(symbol string) error {
+	return fmt.Errorf("%w: symbol %q is not valid UTF-8", errCharListInvalid, symbol)
+}
+
+func newSymbolDuplicatedError(symbol string) error {
+	return fmt.Errorf("%w: duplicated symbol %q", errCharListInvalid, symbol)
+}
+
+func newSymbolPrefixError(symbol, prefix string) error {
+	return fmt.Errorf("%w: symbol %q starts with symbol %q", errCharListInvalid, symbol, prefix)
+}
+
+func newSymbolUnknownError(position int) error {
+	return fmt.Errorf("no symbol matches the id at byte %d", position)
+}
+
+type SymbolTable struct {
+	symbols       [][]byte
+	symbolsByByte [256][]byte
+}
+
+func NewSymbolTable(symbols []string) *SymbolTable {
+	st := &SymbolTable{
+		symbols: make([][]byte, len(symbols)),
+	}
+
+	for i, symbol := range symbols {
+		st.symbols[i] = []byte(symbol)
+		st.symbolsByByte[symbol[0]] = append(st.symbolsByByte[symbol[0]], byte(i))
+	}
+
+	return st
+}
+
+func (st *SymbolTable) Indices() []byte {
+	indices := make([]byte, len(st.symbols))
+	for i := range indices {
+		indices[i] = byte(i)
+	}
+
+	return indices
+}
+
+func (st *SymbolTable) Render(indices []byte) []byte {
+	length := 0
+	for _, index := range indices {
+		length += len(st.symbols[index])
+	}
+
+	return st.AppendRender(make([]byte, 0, length), indices)
+}
+
+// AppendRender appends the symbols of the indices to dst and returns the extended buffer.
+func (st *SymbolTable) AppendRender(dst, indices []byte) []byte {
+	for _, index := range indices {
+		dst = append(dst, st.symbols[index]...)
+	}
+
+	return dst
+}
+
+func (st *SymbolTable) Parse(id []byte, idLength int) ([]byte, error) {
+	indices := make([]byte, 0, idLength)
+	for position := 0; position < len(id); {
+		index, ok := st.match(id[position:])
+		if !ok {
+			return nil, newSymbolUnknownError(position)
+		}
+
+		indices = append(indices, index)
+		position += len(st.symbols[index])
+	}
+
+	if len(indices) != idLength {
+		return nil, newIdLengthMismatchError(len(indices), idLength)
+	}
+	return indices, nil
+}
+
+func (st *SymbolTable) match(id []byte) (byte, bool) {
+	for _, index := range st.symbolsByByte[id[0]] {
+		if bytes.HasPrefix(id, st.symbols[index]) {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+func ValidateSymbols(symbols []string, charList []byte) error {
+	if len(charList) > 0 {
+		return errSymbolsWithChars
+	}
+
+	if len(symbols) == 0 {
+		return errCharListEmpty
+	}
+
+	if len(symbols) > maxSymbols {
+		return errSymbolsTooMany
+	}
+
+	for i, symbol := range symbols {
+		if symbol == "" {
+			return errSymbolEmpty
+		}
+
+		if !utf8.ValidString(symbol) {
+			return newSymbolInvalidUTF8Error(symbol)
+		}
+
+		for j, other := range symbols {
+			if i != j && len(other) <= len(symbol) && symbol[:len(other)] == other {
+				if other == symbol {
+					return newSymbolDuplicatedError(symbol)
+				}
+				return newSymbolPrefixError(symbol, other)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	errTemplateInvalid   = errors.New("invalid template")
+	errTemplateWithChars = errors.New("template cannot be used together with length, character lists or symbols")
+)
+
+func newTemplateError(position int, reason string) error {
+	return fmt.Errorf("%w at position %d: %s", errTemplateInvalid, position, reason)
+}
+
+func newLiteralMismatchError(position int, expected byte) error {
+	return fmt.Errorf("character at position %d must be %s", position, string(expected))
+}
+
+// Template describes ids consisting of literal characters and random characters drawn from separate
+// character lists. Only the random characters are generated by the column schedule, literals are
+// inserted when the id is rendered.
+type Template struct {
+	CharLists [][]byte
+
+	literals  []byte
+	positions []int
+}
+
+// ParseTemplate parses a pattern such as "INV-[A-Z]{4}-2025-[0-9]{4}". Every character class in square
+// brackets is one random character, optionally repeated by a count in curly braces. Classes consist of
+// ASCII characters and ranges. All other characters are literals; backslash escapes the special ones.
+func ParseTemplate(pattern string) (*Template, error) {
+	t := &Template{}
+
+	for position := 0; position < len(pattern); position++ {
+		switch char := pattern[position]; char {
+		case '[':
+			charList, end, err := parseCharClass(pattern, position)
+			if err != nil {
+				return nil, err
+			}
+
+			count := 1
+			if end+1 < len(pattern) && pattern[end+1] == '{' {
+				count, end, err = parseRepetition(pattern, end+1)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if count > maxIdLength-len(t.literals) {
+				return nil, newTemplateError(position, fmt.Sprintf("ids cannot be longer than %d characters", maxIdLength))
+			}
+
+			for i := 0; i < count; i++ {
+				t.CharLists = append(t.CharLists, charList)
+				t.positions = append(t.positions, len(t.literals))
+				t.literals = append(t.literals, 0)
+			}
+			position = end
+		case ']', '{', '}':
+			return nil, newTemplateError(position, fmt.Sprintf("unexpected %s", string(char)))
+		case '\\':
+			if position+1 == len(pattern) {
+				return nil, newTemplateError(position, "nothing to escape")
+			}
+
+			position++
+			t.literals = append(t.literals, pattern[position])
+		default:
+			t.literals = append(t.literals, char)
+		}
+	}
+
+	if len(t.CharLists) == 0 {
+		return nil, newTemplateError(len(pattern), "no character class")
+	}
+
+	return t, nil
+}
+
+func parseCharClass(pattern string, start int) ([]byte, int, error) {
+	var charList []byte
+	seen := [256]bool{}
+	add := func(position int, char byte) error {
+		if char >= 0x80 {
+			return newTemplateError(position, "character classes must consist of ASCII characters")
+		}
+		if seen[char] {
+			return newTemplateError(position, fmt.Sprintf("duplicated character %s", string(char)))
+		}
+
+		seen[char] = true
+		charList = append(charList, char)
+		return nil
+	}
+
+	for position := start + 1; position < len(pattern); position++ {
+		char := pattern[position]
+		switch {
+		case char == ']':
+			if len(charList) == 0 {
+				return nil, 0, newTemplateError(start, "empty character class")
+			}
+			return charList, position, nil
+		case char == '\\':
+			if position+1 == len(pattern) {
+				return nil, 0, newTemplateError(position, "nothing to escape")
+			}
+
+			position++
+			char = pattern[position]
+		case position+2 < len(pattern) && pattern[position+1] == '-' && pattern[position+2] != ']':
+			last := pattern[position+2]
+			if last < char {
+				return nil, 0, newTemplateError(position, fmt.Sprintf("invalid range %s-%s", string(char), string(last)))
+			}
+
+			for c := int(char); c <= int(last); c++ {
+				if err := add(position, byte(c)); err != nil {
+					return nil, 0, err
+				}
+			}
+			position += 2
+			continue
+		}
+
+		if err := add(position, char); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return nil, 0, newTemplateError(start, "unterminated character class")
+}
+
+func parseRepetition(pattern string, start int) (int, int, error) {
+	end := strings.IndexByte(pattern[start:], '}')
+	if end < 0 {
+		return 0, 0, newTemplateError(start, "unterminated count")
+	}
+	end += start
+
+	count, err := strconv.Atoi(pattern[start+1 : end])
+	if err != nil || count <= 0 {
+		return 0, 0, newTemplateError(start, "count must be a positive number")
+	}
+
+	return count, end, nil
+}
+
+// Render returns the id with the random characters taken from the columns and literals in between.
+func (t *Template) Render(columns []byte) []byte {
+	return t.AppendRender(make([]byte, 0, len(t.literals)), columns)
+}
+
+// AppendRender appends the rendered id to dst and returns the extended buffer.
+func (t *Template) AppendRender(dst, columns []byte) []byte {
+	start := len(dst)
+	dst = append(dst, t.literals...)
+
+	for i, position := range t.positions {
+		dst[start+position] = columns[i]
+	}
+
+	return dst
+}
+
+// Segments returns the alternatives for every position of the rendered ids, given the alternatives
+// for the random columns. Every literal is a segment with a single alternative.
+func (t *Template) Segments(columns [][][]byte) [][][]byte {
+	segments := make([][][]byte, len(t.literals))
+	for position, literal := range t.literals {
+		segments[position] = [][]byte{{literal}}
+	}
+
+	for i, position := range t.positions {
+		segments[position] = columns[i]
+	}
+
+	return segments
+}
+
+// Parse checks the literals of the id and returns its random characters.
+func (t *Template) Parse(id []byte) ([]byte, error) {
+	if len(id) != len(t.literals) {
+		return nil, newIdLengthMismatchError(len(id), len(t.literals))
+	}
+
+	columns := make([]byte, len(t.positions))
+	next := 0
+	for position, char := range id {
+		if next < len(t.positions) && t.positions[next] == position {
+			columns[next] = char
+			next++
+			continue
+		}
+
+		if char != t.literals[position] {
+			return nil, newLiteralMismatchError(position, t.literals[position])
+		}
+	}
+
+	if err := ValidateID(columns, t.CharLists); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// ValidateTemplate returns an error if the template is combined with other ways of specifying the characters.
+func ValidateTemplate(idLength int, charList []byte, charLists [][]byte, symbols []string) error {
+	if idLength != 0 || len(charList) > 0 || len(charLists) > 0 || len(symbols) > 0 {
+		return errTemplateWithChars
+	}
+	return nil
+}
@@ -1,23 +1,59 @@
 package internal
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"math"
 )
 
+// maxIdLength is the maximum length of ids, so that a large length or count of repetitions in a template
+// is rejected instead of allocating the columns.
+const maxIdLength = 1 << 16
+
 var (
 	errIdsToGenerateInvalid = errors.New("idsToGenerate must be greater than zero")
 	errIdLengthInvalid      = errors.New("idLength must be greater than zero")
+	errIdLengthTooLong      = fmt.Errorf("idLength must not be greater than %d", maxIdLength)
+	errIdsGeneratedInvalid  = errors.New("idsGenerated must be between zero and the number of ids in the shard")
+	errTotalShardsInvalid   = errors.New("totalShards must be greater than zero")
+	errShardIndexInvalid    = errors.New("shardIndex must be between zero and totalShards - 1")
+	errWorkersInvalid       = errors.New("workers must be greater than zero")
+	errBufferSizeInvalid    = errors.New("bufferSize must not be negative")
+	errMemoryLimitInvalid   = errors.New("memoryLimit must not be negative")
+	errEncoderInvalid       = errors.New("unknown encoder")
+
+	errSeedWithReader    = errors.New("seed and random reader cannot be used together")
+	errShardsWithoutSeed = errors.New("sharding requires a seed")
 
-	errCharListInvalid = errors.New("invalid character list")
-	errCharListEmpty   = fmt.Errorf("%w: empty", errCharListInvalid)
+	errCharListInvalid  = errors.New("invalid character list")
+	errCharListEmpty    = fmt.Errorf("%w: empty", errCharListInvalid)
+	errColumnsEmpty     = errors.New("at least one random character is required")
+	errColumnsTooMany   = fmt.Errorf("at most %d random characters are allowed", maxIdLength)
+	errCharListTooShort = fmt.Errorf("%w: at least two characters are required for more than one unique ID", errCharListInvalid)
+
+	errCharListsWithChars = errors.New("character lists cannot be used together with length, character list or symbols")
 )
 
+func newIdLengthMismatchError(idLength, expectedLength int) error {
+	return fmt.Errorf("id length is %d, expected %d", idLength, expectedLength)
+}
+
+func newUnknownCharacterError(unknown byte) error {
+	return fmt.Errorf("character %s is not in the character list", string(unknown))
+}
+
 func newCharacterDuplicatedError(duplicated byte) error {
 	return fmt.Errorf("%w: duplicated character %s", errCharListInvalid, string(duplicated))
 }
 
+func newColumnsUniquenessError(idsToGenerate, totalColumns, maxToGenerate int) error {
+	return fmt.Errorf(
+		"impossible to generate %d unique IDs with %d random characters each; maximum of %d unique IDs can be generated",
+		idsToGenerate, totalColumns, maxToGenerate,
+	)
+}
+
 func newUniquenessError(idsToGenerate, idLength, totalChars, maxToGenerate int) error {
 	return fmt.Errorf(
 		"impossible to generate %d unique IDs with %d length each and %d total chars; maximum of %d unique IDs can be generated",
@@ -34,8 +70,88 @@ func Validate(idsToGenerate, idLength int, charList []byte) error {
 		return errIdLengthInvalid
 	}
 
+	if idLength > maxIdLength {
+		return errIdLengthTooLong
+	}
+
+	err := validateCharList(charList)
+	if err != nil {
+		return err
+	}
+
 	totalChars := len(charList)
-	if totalChars == 0 {
+	maxToGenerate := pow(totalChars, idLength)
+	if idsToGenerate > maxToGenerate {
+		return newUniquenessError(idsToGenerate, idLength, totalChars, maxToGenerate)
+	}
+	return nil
+}
+
+// MinLength returns the minimum length of ids allowing to generate the given number of unique ids.
+func MinLength(idsToGenerate int, charList []byte) (int, error) {
+	if idsToGenerate <= 0 {
+		return 0, errIdsToGenerateInvalid
+	}
+
+	err := validateCharList(charList)
+	if err != nil {
+		return 0, err
+	}
+
+	if idsToGenerate > 1 && len(charList) == 1 {
+		return 0, errCharListTooShort
+	}
+
+	idLength, maxToGenerate := 1, len(charList)
+	for maxToGenerate < idsToGenerate {
+		idLength++
+		maxToGenerate = multiply(maxToGenerate, len(charList))
+	}
+	return idLength, nil
+}
+
+// ValidateColumns validates a set of ids with a separate character list for every random column.
+// The number of unique ids is the product of the sizes of all character lists.
+func ValidateColumns(idsToGenerate int, charLists [][]byte) error {
+	if idsToGenerate <= 0 {
+		return errIdsToGenerateInvalid
+	}
+
+	if len(charLists) == 0 {
+		return errColumnsEmpty
+	}
+
+	if len(charLists) > maxIdLength {
+		return errColumnsTooMany
+	}
+
+	maxToGenerate := 1
+	for _, charList := range charLists {
+		err := validateCharList(charList)
+		if err != nil {
+			return err
+		}
+
+		maxToGenerate = multiply(maxToGenerate, len(charList))
+	}
+
+	if idsToGenerate > maxToGenerate {
+		return newColumnsUniquenessError(idsToGenerate, len(charLists), maxToGenerate)
+	}
+	return nil
+}
+
+// ValidateCharLists returns an error if the lists of characters for every position are combined
+// with other ways of specifying the characters.
+func ValidateCharLists(idLength int, charList []byte, symbols []string) error {
+	if idLength != 0 || len(charList) > 0 || len(symbols) > 0 {
+		return errCharListsWithChars
+	}
+	return nil
+}
+
+func validateCharList(charList []byte) error {
+	if len(charList) == 0 {
 		return errCharListEmpty
 	}
 
@@ -47,10 +163,79 @@ func Validate(idsToGenerate, idLength int, charList []byte) error {
 		}
 		uniqueChars[char] = struct{}{}
 	}
+	return nil
+}
+
+// RepeatCharList returns the character lists of a set of ids using the same character list in every column.
+func RepeatCharList(charList []byte, idLength int) [][]byte {
+	charLists := make([][]byte, idLength)
+	for i := range charLists {
+		charLists[i] = charList
+	}
 
-	maxToGenerate := pow(totalChars, idLength)
-	if idsToGenerate > maxToGenerate {
-		return newUniquenessError(idsToGenerate, idLength, totalChars, maxToGenerate)
+	return charLists
+}
+
+func ValidateShard(shardIndex, totalShards int) error {
+	if totalShards <= 0 {
+		return errTotalShardsInvalid
+	}
+
+	if shardIndex < 0 || shardIndex >= totalShards {
+		return errShardIndexInvalid
+	}
+	return nil
+}
+
+func ValidateRandomness(seeded, withReader bool, totalShards int) error {
+	if seeded && withReader {
+		return errSeedWithReader
+	}
+
+	if !seeded && totalShards > 1 {
+		return errShardsWithoutSeed
+	}
+	return nil
+}
+
+func ValidateSettings(encoder, bufferSize, memoryLimit int) error {
+	if encoder < 0 || encoder > 1 {
+		return errEncoderInvalid
+	}
+
+	if bufferSize < 0 {
+		return errBufferSizeInvalid
+	}
+
+	if memoryLimit < 0 {
+		return errMemoryLimitInvalid
+	}
+	return nil
+}
+
+func ValidateWorkers(workers int) error {
+	if workers <= 0 {
+		return errWorkersInvalid
+	}
+	return nil
+}
+
+func ValidatePosition(idsGenerated, idsInShard int) error {
+	if idsGenerated < 0 || idsGenerated > idsInShard {
+		return errIdsGeneratedInvalid
+	}
+	return nil
+}
+
+func ValidateID(id []byte, charLists [][]byte) error {
+	if len(id) != len(charLists) {
+		return newIdLengthMismatchError(len(id), len(charLists))
+	}
+
+	for i, char := range id {
+		if bytes.IndexByte(charLists[i], char) < 0 {
+			return newUnknownCharacterError(char)
+		}
 	}
 	return nil
 }
@@ -58,10 +243,14 @@ func Validate(idsToGenerate, idLength int, charList []byte) error {
 func pow(base, exponent int) int {
 	n := 1
 	for i := 0; i < exponent; i++ {
-		n *= base
-		if n <= 0 {
-			return math.MaxInt
-		}
+		n = multiply(n, base)
 	}
 	return n
 }
+
+func multiply(a, b int) int {
+	if a > math.MaxInt/b {
+		return math.MaxInt
+	}
+	return a * b
+}
@@ -0,0 +1,186 @@
+package generateids
+
+import (
+	"context"
+	"iter"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// Iterator is a pull-style alternative to the channel returned by the Channel method.
+// Ids are generated synchronously in the goroutine calling Next, so no goroutine or channel is involved.
+// Iterator is not safe for concurrent use.
+type Iterator struct {
+	ctx context.Context
+	seq *sequence
+}
+
+// Iterator method returns an Iterator over the set of ids specified in the Generator constructor.
+// Like Array and Channel methods, it can be used only once per Generator.
+func (g *Generator) Iterator(ctx context.Context) (*Iterator, error) {
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Iterator{
+		ctx: ctx,
+		seq: g.newSequence(),
+	}, nil
+}
+
+// Next generates the next id. It returns false when all ids are generated or the context is cancelled.
+func (it *Iterator) Next() ([]byte, bool) {
+	return it.seq.next(it.ctx)
+}
+
+// Err returns the same error as the InterruptionErr method of the Generator: nil if Next returned false
+// because all ids are generated, or wrapped context error if the context was cancelled.
+func (it *Iterator) Err() error {
+	return it.seq.g.InterruptionErr()
+}
+
+// All method returns a single-use iterator over the set of ids specified in the Generator constructor,
+// to be used with the range statement. Ids are generated synchronously in the ranging goroutine.
+// If the context is cancelled, iteration stops and wrapped context error is available
+// from the InterruptionErr method. Breaking out of the loop early does not set any error,
+// and the progress remains available from the Checkpoint method.
+func (g *Generator) All(ctx context.Context) (iter.Seq[[]byte], error) {
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seq := g.newSequence()
+	return func(yield func([]byte) bool) {
+		for id, ok := seq.next(ctx); ok; id, ok = seq.next(ctx) {
+			if !yield(id) {
+				return
+			}
+		}
+	}, nil
+}
+
+// sequence generates ids one by one in the calling goroutine, using the column schedule of the Generator.
+// Concurrent generators are also handled, by generating their partitions in order.
+type sequence struct {
+	g            *Generator
+	schedule     internal.Schedule
+	columns      *internal.ColumnsGenerator
+	idsGenerated int
+	stateSaved   int
+	started      bool
+	finished     bool
+}
+
+func (g *Generator) newSequence() *sequence {
+	s := &sequence{g: g, idsGenerated: g.idsGenerated}
+
+	var schedule columnsSchedule
+	idsLeft := g.scheduleSize
+	if g.restored != nil {
+		schedule, idsLeft = g.restored, g.restored.IdsLeft()
+	} else {
+		schedule = g.newColumnsSchedule(g.random)
+	}
+
+	// states of the column schedule are saved for checkpoints only if it can be restored from them
+	if g.counting != nil {
+		s.columns, _ = schedule.(*internal.ColumnsGenerator)
+	}
+	s.schedule = g.filter(schedule, idsLeft)
+
+	return s
+}
+
+// next returns the next id, or false if there are no more ids to generate. When generating is interrupted,
+// the interruption error is set on the Generator.
+func (s *sequence) next(ctx context.Context) ([]byte, bool) {
+	id := make([]byte, s.g.idLength)
+	if !s.nextColumns(ctx, id) {
+		return nil, false
+	}
+
+	return s.g.finishId(id), true
+}
+
+// nextColumns takes the next id from the column schedule into the given buffer, without encoding it.
+func (s *sequence) nextColumns(ctx context.Context, id []byte) bool {
+	if s.finished {
+		return false
+	}
+
+	if !s.started {
+		s.started = true
+		if err := s.start(ctx); err != nil {
+			s.interrupt(err)
+			return false
+		}
+	}
+
+	if s.idsGenerated >= s.g.shardEnd {
+		s.finished = true
+		return false
+	}
+
+	if err := s.g.interruption(ctx); err != nil {
+		s.interrupt(err)
+		return false
+	}
+
+	s.schedule.Next(id)
+	if err := scheduleErr(s.schedule); err != nil {
+		s.interrupt(err)
+		return false
+	}
+	if err := s.g.sourceErr(); err != nil {
+		s.interrupt(err)
+		return false
+	}
+
+	s.idsGenerated++
+	s.g.setIdsGenerated(s.idsGenerated)
+
+	if s.columns != nil && s.idsGenerated-s.stateSaved > stateInterval+s.g.bufferSize {
+		s.saveState()
+	}
+
+	return true
+}
+
+// cancelLast interrupts the sequence when the last returned id could not be delivered,
+// so that it is not counted as generated.
+func (s *sequence) cancelLast(err error) {
+	s.idsGenerated--
+	s.g.setIdsGenerated(s.idsGenerated)
+	s.interrupt(err)
+}
+
+// start advances the column schedule to the ids generated before, from the restored state if there is one.
+func (s *sequence) start(ctx context.Context) error {
+	from := s.g.restoredState
+	err := skipDraws(ctx, s.g.counting, from.draws)
+	if err != nil {
+		return err
+	}
+
+	err = skipSchedule(ctx, s.schedule, s.idsGenerated-from.idsGenerated)
+	if err != nil {
+		return err
+	}
+
+	if s.columns != nil {
+		s.saveState()
+	}
+	return nil
+}
+
+func (s *sequence) saveState() {
+	s.g.saveState(s.idsGenerated, s.columns)
+	s.stateSaved = s.idsGenerated
+}
+
+func (s *sequence) interrupt(err error) {
+	s.finished = true
+	s.g.setInterruptionErr(s.idsGenerated, err)
+}
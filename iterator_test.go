@@ -0,0 +1,165 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGenerator_Iterator(t *testing.T) {
+	t.Run("returns the same ids as array", func(t *testing.T) {
+		seed := int64(0)
+		expected := generateIdsWithSeed(t, 1024, 128, charsAlphanumeric, seed)
+
+		generator, err := NewGeneratorWithSeed(1024, 128, charsAlphanumeric, seed)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		it, err := generator.Iterator(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected iterator method error: %s", err)
+		}
+
+		var results [][]byte
+		for id, ok := it.Next(); ok; id, ok = it.Next() {
+			results = append(results, id)
+		}
+
+		if it.Err() != nil {
+			t.Errorf("expected no iterator error, got %v", it.Err())
+		}
+		assertSameIds(t, expected, results)
+	})
+
+	t.Run("stops generating ids when context is cancelled", func(t *testing.T) {
+		generator, err := NewGenerator(1024, 128, charsAlphanumeric)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		it, err := generator.Iterator(ctx)
+		if err != nil {
+			t.Fatalf("unexpected iterator method error: %s", err)
+		}
+
+		idsCount := 0
+		for _, ok := it.Next(); ok; _, ok = it.Next() {
+			if idsCount++; idsCount == 10 {
+				cancel()
+			}
+		}
+
+		if idsCount != 10 {
+			t.Errorf("expected %d ids, got %d", 10, idsCount)
+		}
+		if !errors.Is(it.Err(), context.Canceled) {
+			t.Errorf("expected iterator error to be context error, got %v", it.Err())
+		}
+		if _, ok := it.Next(); ok {
+			t.Errorf("expected no ids after interruption")
+		}
+	})
+
+	t.Run("can be used only once", func(t *testing.T) {
+		generator, err := NewGenerator(4, 2, charsAB)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		if _, err = generator.Iterator(context.Background()); err != nil {
+			t.Fatalf("unexpected iterator method error: %s", err)
+		}
+
+		if _, err = generator.Iterator(context.Background()); !errors.Is(err, ErrUsed) {
+			t.Errorf("expected %v, got %v", ErrUsed, err)
+		}
+		if _, err = generator.All(context.Background()); !errors.Is(err, ErrUsed) {
+			t.Errorf("expected %v, got %v", ErrUsed, err)
+		}
+	})
+}
+
+func TestGenerator_All(t *testing.T) {
+	t.Run("returns the same ids as array for concurrent generator", func(t *testing.T) {
+		seed := int64(0)
+		expected := generateConcurrentIdsWithSeed(t, 20000, 16, charsAlphanumeric, seed, 4)
+
+		generator, err := NewConcurrentGeneratorWithSeed(20000, 16, charsAlphanumeric, seed, 4)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ids, err := generator.All(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected all method error: %s", err)
+		}
+
+		var results [][]byte
+		for id := range ids {
+			results = append(results, id)
+		}
+
+		if generator.InterruptionErr() != nil {
+			t.Errorf("expected no interruptionErr, got %v", generator.InterruptionErr())
+		}
+		assertSameIds(t, expected, results)
+	})
+
+	t.Run("can be resumed from checkpoint after breaking out of the loop", func(t *testing.T) {
+		seed := int64(0)
+		expected := generateIdsWithSeed(t, 1024, 16, charsAlphanumeric, seed)
+
+		generator, err := NewGeneratorWithSeed(1024, 16, charsAlphanumeric, seed)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ids, err := generator.All(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected all method error: %s", err)
+		}
+
+		var results [][]byte
+		for id := range ids {
+			results = append(results, id)
+			if len(results) == 100 {
+				break
+			}
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(checkpoint)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		rest, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		assertSameIds(t, expected, append(results, rest...))
+	})
+}
+
+func assertSameIds(t *testing.T, expected, results [][]byte) {
+	t.Helper()
+
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+
+	for index, id := range expected {
+		if string(id) != string(results[index]) {
+			t.Fatalf("expected %s at %d, got %s", id, index, results[index])
+		}
+	}
+}
@@ -0,0 +1,324 @@
+package generateids
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/wfabjanczuk/generateids/internal"
+)
+
+// Encoder selects how the ids are scrambled after being taken from the column schedule.
+type Encoder int
+
+const (
+	// EncoderSymmetric replaces pairs of characters placed symmetrically around the middle of the id
+	// (and the middle character of ids of odd length) according to a random permutation. This is the default.
+	EncoderSymmetric Encoder = iota
+	// EncoderNone returns the ids in their column form, in which ids sharing a prefix are placed next to each other.
+	EncoderNone
+)
+
+// Option configures a Generator created with New.
+type Option func(*config)
+
+type config struct {
+	idsToGenerate int
+	idLength      int
+	charList      []byte
+	charLists     [][]byte
+	symbols       []string
+	template      string
+	templated     bool
+	blocklist     Blocklist
+	exclusion     Exclusion
+	check         CheckAlgorithm
+	seed          int64
+	seeded        bool
+	randomReader  io.Reader
+	encoding      Encoder
+	bufferSize    int
+	memoryLimit   int
+	shardIndex    int
+	totalShards   int
+	partitioned   bool
+	workers       int
+	ordered       bool
+	idsGenerated  int
+	state         []byte
+}
+
+// New is a constructor configured with options. The number of ids to generate, length of each id and list
+// of characters are required, other options are optional. Unless WithSeed or WithRandomReader is given,
+// internal random number generator is seeded with the current time in nanoseconds.
+func New(opts ...Option) (*Generator, error) {
+	c := config{
+		encoding:    EncoderSymmetric,
+		bufferSize:  bufferSize,
+		totalShards: 1,
+		workers:     1,
+		ordered:     true,
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return newGenerator(c)
+}
+
+// WithCount sets the number of ids to generate.
+func WithCount(idsToGenerate int) Option {
+	return func(c *config) {
+		c.idsToGenerate = idsToGenerate
+	}
+}
+
+// WithLength sets the length of each id, at most 65536.
+func WithLength(idLength int) Option {
+	return func(c *config) {
+		c.idLength = idLength
+	}
+}
+
+// WithCharList sets the list of characters (bytes) to generate the ids from.
+func WithCharList(charList []byte) Option {
+	return func(c *config) {
+		c.charList = charList
+	}
+}
+
+// WithCharLists sets a separate list of characters for every position of the ids, instead of their length
+// and a single list of characters, e.g. to make the first character a letter and the rest alphanumeric.
+// The length of the ids is the number of lists, at most 65536, and the number of unique ids is the product of their sizes.
+// The encoder keeps every character within the list of its position, so the constraint holds for all ids.
+func WithCharLists(charLists [][]byte) Option {
+	return func(c *config) {
+		c.charLists = charLists
+	}
+}
+
+// WithSymbols sets the list of symbols to generate the ids from, instead of a list of characters (bytes).
+// Each symbol can be any non-empty UTF-8 string, e.g. a single Cyrillic letter or an emoji, as long as no symbol
+// is a prefix of another one, so that ids can be split back into symbols. The length of each id is counted
+// in symbols, and up to 256 symbols can be used. It cannot be combined with WithCharList.
+func WithSymbols(symbols []string) Option {
+	return func(c *config) {
+		c.symbols = symbols
+	}
+}
+
+// Runes splits a string into symbols of one rune each, to be used with WithSymbols.
+func Runes(s string) []string {
+	symbols := make([]string, 0, utf8.RuneCountInString(s))
+	for _, r := range s {
+		symbols = append(symbols, string(r))
+	}
+
+	return symbols
+}
+
+// WithTemplate sets a pattern of the ids instead of their length and list of characters. The pattern consists
+// of literal characters and character classes, e.g. "INV-[A-Z0-9]{4}-2025-[0-9]{4}". Each class in square
+// brackets, with characters and ranges, is one random character; the count in curly braces repeats it.
+// Backslash escapes special characters in literals and classes. Uniqueness is guaranteed over the whole id,
+// and the number of unique ids is the product of the sizes of all classes. The encoder permutes characters
+// only within their classes, so every generated id matches the pattern.
+func WithTemplate(pattern string) Option {
+	return func(c *config) {
+		c.template = pattern
+		c.templated = true
+	}
+}
+
+// WithBlocklist sets words and substrings which must not appear in the ids. Blocked ids are skipped,
+// and the Generator still returns exactly the requested number of unique ids, as long as the set has enough
+// ids without blocked terms. Positions of ids in At, IndexOf, checkpoints and shards are counted
+// in the returned ids only. Concurrent generators with a blocklist generate ids in a single goroutine.
+func WithBlocklist(blocklist Blocklist) Option {
+	return func(c *config) {
+		c.blocklist = blocklist
+	}
+}
+
+// WithExclusion sets ids which must not be returned, e.g. those issued before. Excluded ids are skipped
+// the same way as those matching WithBlocklist, and the Generator still returns exactly the requested number
+// of unique ids. The constructor returns a validation error if the set has not enough ids left, counting
+// all Len ids of the exclusion as belonging to the set. Checkpoints are not supported with an exclusion.
+func WithExclusion(exclusion Exclusion) Option {
+	return func(c *config) {
+		c.exclusion = exclusion
+	}
+}
+
+// WithCheckCharacters appends one or two check characters computed with the algorithm to every id,
+// see CheckAlgorithm. The length of the ids and their number are set for the ids without check characters,
+// which can be verified with the standalone Verify function. It requires WithCharList or WithSymbols.
+func WithCheckCharacters(algorithm CheckAlgorithm) Option {
+	return func(c *config) {
+		c.check = algorithm
+	}
+}
+
+// WithSeed sets custom seed for the internal random number generator. It cannot be combined with WithRandomReader.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// WithRandomReader sets a source of random bytes, such as crypto/rand.Reader, for the internal random number
+// generator. See NewGeneratorWithReader for details. It cannot be combined with WithSeed.
+func WithRandomReader(randomReader io.Reader) Option {
+	return func(c *config) {
+		c.randomReader = randomReader
+	}
+}
+
+// WithEncoder sets the encoder applied to every id. Defaults to EncoderSymmetric.
+func WithEncoder(encoding Encoder) Option {
+	return func(c *config) {
+		c.encoding = encoding
+	}
+}
+
+// WithBufferSize sets the buffer size of the channel returned by the Channel method. Defaults to 100.
+func WithBufferSize(size int) Option {
+	return func(c *config) {
+		c.bufferSize = size
+	}
+}
+
+// WithArrayMemoryLimit caps the memory the Array method may use for the ids, estimated as their bytes
+// and the slice headers of the whole capacity of the array. If the limit is reached, Array returns the ids generated so far
+// together with an error wrapping MemoryLimitError. Defaults to 0, which means no limit.
+func WithArrayMemoryLimit(bytes int) Option {
+	return func(c *config) {
+		c.memoryLimit = bytes
+	}
+}
+
+// WithShard makes the Generator generate only one of totalShards contiguous parts of the set generated
+// with WithWorkers and the same seed, not of the set generated sequentially.
+// See NewShardedGeneratorWithSeed for details. It requires WithSeed.
+func WithShard(shardIndex, totalShards int) Option {
+	return func(c *config) {
+		c.partitioned = true
+		c.shardIndex = shardIndex
+		c.totalShards = totalShards
+	}
+}
+
+// WithWorkers makes the Generator use the given number of worker goroutines.
+// See NewConcurrentGenerator for details.
+func WithWorkers(workers int) Option {
+	return func(c *config) {
+		c.partitioned = true
+		c.workers = workers
+	}
+}
+
+// WithOrderedMerge sets whether ids generated by the workers are merged in order. Defaults to true.
+// See NewConcurrentGeneratorWithSeed for details. It has no effect without WithWorkers.
+func WithOrderedMerge(ordered bool) Option {
+	return func(c *config) {
+		c.ordered = ordered
+	}
+}
+
+func withIdsGenerated(idsGenerated int) Option {
+	return func(c *config) {
+		c.idsGenerated = idsGenerated
+	}
+}
+
+// withScheduleState continues the column schedule from the state stored in a checkpoint.
+func withScheduleState(state []byte) Option {
+	return func(c *config) {
+		c.state = state
+	}
+}
+
+func (c *config) validate() error {
+	err := c.validateCharacters()
+	if err != nil {
+		return err
+	}
+
+	checkCharList := c.charList
+	if c.symbols != nil {
+		checkCharList = internal.NewSymbolTable(c.symbols).Indices()
+	}
+
+	err = internal.ValidateCheck(int(c.check), checkCharList, c.templated || c.charLists != nil)
+	if err != nil {
+		return err
+	}
+
+	err = internal.ValidateBlocklist(c.blocklist.Words, c.blocklist.Substrings)
+	if err != nil {
+		return err
+	}
+
+	err = internal.ValidateRandomness(c.seeded, c.randomReader != nil, c.totalShards)
+	if err != nil {
+		return err
+	}
+
+	err = internal.ValidateShard(c.shardIndex, c.totalShards)
+	if err != nil {
+		return err
+	}
+
+	err = internal.ValidateSettings(int(c.encoding), c.bufferSize, c.memoryLimit)
+	if err != nil {
+		return err
+	}
+
+	if c.partitioned {
+		err = internal.ValidateWorkers(c.workers)
+		if err != nil {
+			return err
+		}
+	}
+
+	shardStart, shardEnd := internal.ShardRange(c.idsToGenerate, c.shardIndex, c.totalShards)
+	return internal.ValidatePosition(c.idsGenerated, shardEnd-shardStart)
+}
+
+func (c *config) validateCharacters() error {
+	if c.templated {
+		err := internal.ValidateTemplate(c.idLength, c.charList, c.charLists, c.symbols)
+		if err != nil {
+			return err
+		}
+
+		template, err := internal.ParseTemplate(c.template)
+		if err != nil {
+			return err
+		}
+
+		return internal.ValidateColumns(c.idsToGenerate, template.CharLists)
+	}
+
+	if c.charLists != nil {
+		err := internal.ValidateCharLists(c.idLength, c.charList, c.symbols)
+		if err != nil {
+			return err
+		}
+
+		return internal.ValidateColumns(c.idsToGenerate, c.charLists)
+	}
+
+	charList := c.charList
+	if c.symbols != nil {
+		err := internal.ValidateSymbols(c.symbols, c.charList)
+		if err != nil {
+			return err
+		}
+
+		charList = internal.NewSymbolTable(c.symbols).Indices()
+	}
+
+	return internal.Validate(c.idsToGenerate, c.idLength, charList)
+}
@@ -0,0 +1,500 @@
+package generateids
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNew_Options(t *testing.T) {
+	t.Run("returns the same results as positional constructor", func(t *testing.T) {
+		seed := int64(0)
+		expected := generateIdsWithSeed(t, 1024, 128, charsAlphanumeric, seed)
+
+		generator, err := New(
+			WithCount(1024), WithLength(128), WithCharList(charsAlphanumeric), WithSeed(seed), WithBufferSize(0),
+		)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		for index, id := range expected {
+			if string(id) != string(results[index]) {
+				t.Errorf("expected %s, got %s", id, results[index])
+			}
+		}
+	})
+
+	t.Run("returns ids in column form without encoder", func(t *testing.T) {
+		generator, err := New(WithCount(1024), WithLength(10), WithCharList(charsAB), WithEncoder(EncoderNone))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		for index, id := range results {
+			if index > 0 && bytes.Compare(results[index-1], id) >= 0 {
+				t.Errorf("expected %s to follow %s in column form", id, results[index-1])
+			}
+
+			foundIndex, err := generator.IndexOf(context.Background(), id)
+			if err != nil {
+				t.Fatalf("unexpected index of method error: %s", err)
+			}
+			if foundIndex != index {
+				t.Errorf("expected index %d for %s, got %d", index, id, foundIndex)
+			}
+		}
+	})
+
+	t.Run("sharded concurrent generators form the same set as a single concurrent generator", func(t *testing.T) {
+		seed := int64(0)
+		expected := generateConcurrentIdsWithSeed(t, 20000, 16, charsAlphanumeric, seed, 2)
+
+		var results [][]byte
+		for shardIndex := 0; shardIndex < 3; shardIndex++ {
+			generator, err := New(
+				WithCount(20000), WithLength(16), WithCharList(charsAlphanumeric), WithSeed(seed),
+				WithShard(shardIndex, 3), WithWorkers(2),
+			)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			idsArray, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+			results = append(results, idsArray...)
+		}
+
+		if len(results) != len(expected) {
+			t.Fatalf("expected %d results, got %d", len(expected), len(results))
+		}
+		for index, id := range expected {
+			if string(id) != string(results[index]) {
+				t.Fatalf("expected %s at %d, got %s", id, index, results[index])
+			}
+		}
+	})
+}
+
+func TestNew_Validation(t *testing.T) {
+	required := []Option{WithCount(4), WithLength(2), WithCharList(charsAB)}
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{"returns error when required options are missing", nil},
+		{"returns error when seed is combined with random reader", []Option{WithSeed(0), WithRandomReader(bytes.NewReader(nil))}},
+		{"returns error when sharding without seed", []Option{WithShard(0, 2)}},
+		{"returns error when buffer size is negative", []Option{WithBufferSize(-1)}},
+		{"returns error when array memory limit is negative", []Option{WithArrayMemoryLimit(-1)}},
+		{"returns error when encoder is unknown", []Option{WithEncoder(Encoder(-1))}},
+		{"returns error when workers is not positive", []Option{WithWorkers(0)}},
+		{"returns error when length is too long", []Option{WithLength(1<<16 + 1)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tc.opts
+			if opts != nil {
+				opts = append(append([]Option{}, required...), opts...)
+			}
+
+			_, err := New(opts...)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestNew_Symbols(t *testing.T) {
+	symbols := Runes("абвгд🙂")
+
+	t.Run("returns only unique ids of valid symbols", func(t *testing.T) {
+		generator, err := New(WithCount(6*6*6), WithLength(3), WithSymbols(symbols), WithSeed(1))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		uniqueIds := make(map[string]struct{}, len(results))
+		for _, id := range results {
+			if !utf8.Valid(id) || utf8.RuneCount(id) != 3 {
+				t.Errorf("expected 3 valid symbols, got %q", id)
+			}
+			uniqueIds[string(id)] = struct{}{}
+		}
+
+		if len(uniqueIds) != 6*6*6 {
+			t.Errorf("expected %d unique ids, got %d", 6*6*6, len(uniqueIds))
+		}
+	})
+
+	t.Run("decodes and finds every id", func(t *testing.T) {
+		generator, err := New(WithCount(100), WithLength(4), WithSymbols([]string{"ab", "c", "ñ", "🙂"}), WithSeed(2))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		for index := 0; index < 100; index += 9 {
+			id, err := generator.At(context.Background(), index)
+			if err != nil {
+				t.Fatalf("unexpected at method error: %s", err)
+			}
+
+			foundIndex, err := generator.IndexOf(context.Background(), id)
+			if err != nil {
+				t.Fatalf("unexpected index of method error: %s", err)
+			}
+			if foundIndex != index {
+				t.Errorf("expected index %d of %q, got %d", index, id, foundIndex)
+			}
+
+			if _, err = generator.Decode(id); err != nil {
+				t.Errorf("unexpected decode method error: %s", err)
+			}
+		}
+
+		if _, err = generator.Decode([]byte("abcX")); !errors.Is(err, ErrInvalidID) {
+			t.Errorf("expected invalid id error, got %v", err)
+		}
+	})
+
+	t.Run("resumed generator returns the rest of the set", func(t *testing.T) {
+		opts := []Option{WithCount(200), WithLength(4), WithSymbols(symbols), WithSeed(3)}
+		expectedGenerator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		expected, err := expectedGenerator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		generator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		iterator, err := generator.Iterator(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected iterator method error: %s", err)
+		}
+		for i := 0; i < 50; i++ {
+			iterator.Next()
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint method error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, expected[50:], results)
+	})
+
+	t.Run("returns error when symbols are invalid", func(t *testing.T) {
+		invalidSymbols := [][]string{
+			{},
+			{"a", ""},
+			{"a", "b", "a"},
+			{"a", "ab"},
+			{"a", "\xff"},
+		}
+
+		for _, symbols := range invalidSymbols {
+			_, err := New(WithCount(1), WithLength(1), WithSymbols(symbols))
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error for symbols %q, got %v", symbols, err)
+			}
+		}
+
+		_, err := New(WithCount(1), WithLength(1), WithSymbols(symbols), WithCharList(charsAB))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error when combined with char list, got %v", err)
+		}
+	})
+}
+
+func TestNew_Template(t *testing.T) {
+	pattern := `INV-[A-Z]{2}[0-9]-\[[ab]\]`
+	idsToGenerate := 26 * 26 * 10 * 2
+
+	t.Run("returns only unique ids matching the template", func(t *testing.T) {
+		generator, err := New(WithCount(idsToGenerate), WithTemplate(pattern), WithSeed(1))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		matcher := regexp.MustCompile(`^INV-[A-Z]{2}[0-9]-\[[ab]\]$`)
+		uniqueIds := make(map[string]struct{}, len(results))
+		for _, id := range results {
+			if !matcher.Match(id) {
+				t.Errorf("expected id matching the template, got %s", id)
+			}
+			uniqueIds[string(id)] = struct{}{}
+		}
+
+		if len(uniqueIds) != idsToGenerate {
+			t.Errorf("expected %d unique ids, got %d", idsToGenerate, len(uniqueIds))
+		}
+	})
+
+	t.Run("decodes and finds ids", func(t *testing.T) {
+		generator, err := New(WithCount(100), WithTemplate(pattern), WithSeed(2))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		id, err := generator.At(context.Background(), 42)
+		if err != nil {
+			t.Fatalf("unexpected at method error: %s", err)
+		}
+
+		index, err := generator.IndexOf(context.Background(), id)
+		if err != nil || index != 42 {
+			t.Errorf("expected index 42 of %s, got %d, %v", id, index, err)
+		}
+
+		for _, invalidId := range []string{"INV-AB1-[c]", "INX-AB1-[a]", "INV-AB1-[a"} {
+			if _, err = generator.Decode([]byte(invalidId)); !errors.Is(err, ErrInvalidID) {
+				t.Errorf("expected invalid id error for %s, got %v", invalidId, err)
+			}
+		}
+	})
+
+	t.Run("concurrent generator returns the same set", func(t *testing.T) {
+		generator, err := New(WithCount(idsToGenerate), WithTemplate(pattern), WithSeed(3), WithWorkers(3))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		uniqueIds := make(map[string]struct{}, len(results))
+		for _, id := range results {
+			uniqueIds[string(id)] = struct{}{}
+		}
+
+		if len(uniqueIds) != idsToGenerate {
+			t.Errorf("expected %d unique ids, got %d", idsToGenerate, len(uniqueIds))
+		}
+	})
+
+	t.Run("resumed generator returns the rest of the set", func(t *testing.T) {
+		opts := []Option{WithCount(500), WithTemplate(pattern), WithSeed(4)}
+		expectedGenerator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		expected, err := expectedGenerator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		generator, err := New(append(opts, withIdsGenerated(100))...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint method error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, expected[100:], results)
+	})
+
+	t.Run("returns error when template is invalid", func(t *testing.T) {
+		testCases := []struct {
+			count int
+			opts  []Option
+		}{
+			{1, []Option{WithTemplate("ID")}},
+			{1, []Option{WithTemplate("[]")}},
+			{1, []Option{WithTemplate("[a-")}},
+			{1, []Option{WithTemplate("[z-a]")}},
+			{1, []Option{WithTemplate("[aa]")}},
+			{1, []Option{WithTemplate("[a]{0}")}},
+			{1, []Option{WithTemplate("[a]{2")}},
+			{1, []Option{WithTemplate("[0-9]{2000000000}")}},
+			{1, []Option{WithTemplate("[0-9]{40000}[a-z]{40000}")}},
+			{1, []Option{WithTemplate("a}")}},
+			{1, []Option{WithTemplate("[ą]")}},
+			{1, []Option{WithTemplate("[a]"), WithLength(1)}},
+			{1, []Option{WithTemplate("[a]"), WithCharList(charsAB)}},
+			{3, []Option{WithTemplate("X[ab]Y")}},
+		}
+
+		for _, tc := range testCases {
+			_, err := New(append([]Option{WithCount(tc.count)}, tc.opts...)...)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		}
+	})
+}
+
+func TestNew_CharLists(t *testing.T) {
+	letters := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	charLists := [][]byte{letters, charsAlphanumeric, charsAlphanumeric, []byte("0123456789")}
+
+	t.Run("returns ids with characters from the lists of their positions", func(t *testing.T) {
+		for _, workers := range []int{0, 2} {
+			opts := []Option{WithCount(20000), WithCharLists(charLists), WithSeed(1)}
+			if workers > 0 {
+				opts = append(opts, WithWorkers(workers))
+			}
+
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			results, err := generator.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+
+			uniqueIds := make(map[string]struct{}, len(results))
+			for _, id := range results {
+				for position, char := range id {
+					if !bytes.Contains(charLists[position], []byte{char}) {
+						t.Fatalf("unexpected character %c at position %d of %s", char, position, id)
+					}
+				}
+				uniqueIds[string(id)] = struct{}{}
+			}
+
+			if len(uniqueIds) != 20000 {
+				t.Errorf("expected %d unique ids, got %d", 20000, len(uniqueIds))
+			}
+		}
+	})
+
+	t.Run("returns the same results as a single char list", func(t *testing.T) {
+		sameCharLists := [][]byte{charsABC, charsABC, charsABC, charsABC, charsABC, charsABC, charsABC}
+		generator, err := New(WithCount(1000), WithCharLists(sameCharLists), WithSeed(2))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := generator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, generateIdsWithSeed(t, 1000, 7, charsABC, 2), results)
+	})
+
+	t.Run("resumed generator returns the rest of the set", func(t *testing.T) {
+		opts := []Option{WithCount(300), WithCharLists(charLists), WithSeed(3)}
+		expectedGenerator, err := New(opts...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		expected, err := expectedGenerator.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+
+		generator, err := New(append(opts, withIdsGenerated(120))...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		checkpoint, err := generator.Checkpoint()
+		if err != nil {
+			t.Fatalf("unexpected checkpoint method error: %s", err)
+		}
+
+		resumed, err := NewGeneratorFromCheckpoint(marshalCheckpoint(t, checkpoint))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		results, err := resumed.Array(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected array method error: %s", err)
+		}
+		assertSameIds(t, expected[120:], results)
+	})
+
+	t.Run("returns error when char lists are invalid", func(t *testing.T) {
+		testCases := [][]Option{
+			{WithCharLists([][]byte{})},
+			{WithCharLists([][]byte{charsAB, {}})},
+			{WithCharLists([][]byte{charsAB, []byte("AA")})},
+			{WithCharLists([][]byte{charsAB, charsAB}), WithLength(2)},
+			{WithCharLists([][]byte{charsAB, charsAB}), WithCharList(charsAB)},
+			{WithCharLists([][]byte{charsAB, charsAB}), WithTemplate("[ab]")},
+		}
+
+		for _, opts := range testCases {
+			_, err := New(append([]Option{WithCount(4)}, opts...)...)
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		}
+
+		_, err := New(WithCount(5), WithCharLists([][]byte{charsAB, charsAB}))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error when capacity is exceeded, got %v", err)
+		}
+
+		charLists := make([][]byte, 1<<16+1)
+		for i := range charLists {
+			charLists[i] = charsAB
+		}
+		_, err = New(WithCount(4), WithCharLists(charLists))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error when ids are too long, got %v", err)
+		}
+	})
+}
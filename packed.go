@@ -0,0 +1,165 @@
+package generateids
+
+import (
+	"context"
+	"unsafe"
+)
+
+// offsetSize is the size of an offset stored by PackedIDs for ids of different lengths.
+const offsetSize = int(unsafe.Sizeof(0))
+
+// PackedIDs stores ids one after another in a single contiguous buffer, so that a large set of ids
+// takes two heap objects instead of one per id. Ids of the same length, which is the case unless symbols
+// of different lengths are used, are indexed without storing any offsets.
+type PackedIDs struct {
+	data    []byte
+	width   int
+	count   int
+	offsets []int
+}
+
+// Len returns the number of ids.
+func (p *PackedIDs) Len() int {
+	return p.count
+}
+
+// At returns the id at the given index, which must be in [0, Len()). The id shares the memory of PackedIDs
+// and must not be modified. Its capacity is limited to its length, so appending to it copies the id.
+func (p *PackedIDs) At(index int) []byte {
+	if p.offsets == nil {
+		start := index * p.width
+		return p.data[start : start+p.width : start+p.width]
+	}
+
+	end := len(p.data)
+	if index+1 < p.count {
+		end = p.offsets[index+1]
+	}
+	return p.data[p.offsets[index]:end:end]
+}
+
+// Slices returns all ids as sub-slices of the buffer of PackedIDs.
+func (p *PackedIDs) Slices() [][]byte {
+	ids := make([][]byte, p.count)
+	for i := range ids {
+		ids[i] = p.At(i)
+	}
+
+	return ids
+}
+
+// append copies the id into the buffer and reports whether the buffer and the offsets fit in the memory limit,
+// if it is set. idsLeft is the number of ids left to append, including this one. The buffer grows at most
+// by the size of the ids left, assuming they are of the same length, so that the buffer of a finished set
+// has no spare capacity. With a limit, the buffer and the offsets grow only by as many ids as fit in it.
+func (p *PackedIDs) append(id []byte, idsLeft, limit int) bool {
+	if p.offsets == nil && p.count > 0 && len(id) != p.width {
+		if limit > 0 && p.memory()+p.count*offsetSize > limit {
+			return false
+		}
+
+		p.offsets = make([]int, p.count)
+		for i := range p.offsets {
+			p.offsets[i] = i * p.width
+		}
+	}
+
+	growData := len(p.data)+len(id) > cap(p.data)
+	growOffsets := p.offsets != nil && len(p.offsets) == cap(p.offsets)
+	if growData || growOffsets {
+		ids := min(max(p.count, arrayChunkSize), idsLeft)
+		if limit > 0 {
+			idMemory := len(id)
+			if p.offsets != nil {
+				idMemory += offsetSize
+			}
+			ids = min(ids, (limit-p.memory())/idMemory)
+		}
+
+		if ids <= 0 {
+			return false
+		}
+		if growData {
+			p.data = grow(p.data, ids*len(id))
+		}
+		if growOffsets {
+			p.offsets = grow(p.offsets, ids)
+		}
+	}
+
+	if p.count == 0 {
+		p.width = len(id)
+	}
+	if p.offsets != nil {
+		p.offsets = append(p.offsets, len(p.data))
+	}
+
+	p.data = append(p.data, id...)
+	p.count++
+	return true
+}
+
+func (p *PackedIDs) memory() int {
+	return cap(p.data) + cap(p.offsets)*offsetSize
+}
+
+// grow returns a copy of the slice with room for exactly n more elements.
+func grow[S ~[]E, E any](s S, n int) S {
+	grown := make(S, len(s), len(s)+n)
+	copy(grown, s)
+	return grown
+}
+
+// Packed method generates the set of ids specified in the Generator constructor and stores them in a single
+// contiguous buffer, which is grown as the ids are generated. Ids are generated in the calling goroutine,
+// also for concurrent generators. Like Array, it respects WithArrayMemoryLimit, counting the capacity
+// of the buffer and of the offsets of ids of different lengths, and returns the ids generated so far
+// with an error wrapping MemoryLimitError when the limit is reached.
+func (g *Generator) Packed(ctx context.Context) (*PackedIDs, error) {
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.packed(ctx)
+}
+
+// ArrayContiguous method is an alternative to Array, which returns the ids as sub-slices of a single
+// contiguous buffer, as returned by the Packed method, instead of allocating every id separately.
+func (g *Generator) ArrayContiguous(ctx context.Context) ([][]byte, error) {
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	packed, err := g.packed(ctx)
+	return packed.Slices(), err
+}
+
+// packed generates the ids synchronously in the calling goroutine and renders every id into a reused buffer,
+// from which it is copied into PackedIDs, so no memory is allocated per id. If the id does not fit
+// in the memory limit, it is not counted as generated, so that generating can be resumed from a checkpoint.
+func (g *Generator) packed(ctx context.Context) (*PackedIDs, error) {
+	packed := &PackedIDs{}
+	if g.IDSize() == 0 {
+		// ids of different sizes are indexed from the start, so that the offsets are counted in the memory limit
+		// as the buffer grows
+		packed.offsets = []int{}
+	}
+
+	seq := g.newSequence()
+	columns := make([]byte, g.idLength, g.idLength+g.checkLength())
+
+	var id []byte
+	for seq.nextColumns(ctx, columns) {
+		id = g.appendId(id[:0], columns)
+		if !packed.append(id, g.shardEnd-seq.idsGenerated+1, g.memoryLimit) {
+			idsGenerated := seq.idsGenerated - 1
+			g.setIdsGenerated(idsGenerated)
+			g.setInterruptionErr(idsGenerated, &MemoryLimitError{Limit: g.memoryLimit})
+			break
+		}
+	}
+
+	return packed, g.InterruptionErr()
+}
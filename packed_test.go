@@ -0,0 +1,172 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"unsafe"
+)
+
+func TestGenerator_Packed(t *testing.T) {
+	testCases := []struct {
+		name string
+		opts []Option
+	}{
+		{"sequential", []Option{WithCount(10_000), WithLength(12), WithCharList(charsAlphanumeric)}},
+		{"concurrent", []Option{WithCount(10_000), WithLength(12), WithCharList(charsAlphanumeric), WithWorkers(4)}},
+		{"template", []Option{WithCount(500), WithTemplate("ID-[A-Z]{3}")}},
+		{"symbols of different lengths", []Option{WithCount(500), WithLength(4), WithSymbols([]string{"a", "bb", "ccc", "δ", "€", "🙂"})}},
+		{"shard", []Option{WithCount(1000), WithLength(4), WithCharList(charsAlphanumeric), WithShard(1, 3)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(tc.opts, WithSeed(9))
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			packed, err := generator.Packed(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected packed method error: %s", err)
+			}
+
+			if packed.Len() != len(expected) {
+				t.Fatalf("expected %d ids, got %d", len(expected), packed.Len())
+			}
+			assertSameIds(t, expected, packed.Slices())
+
+			size := 0
+			for i := 0; i < packed.Len(); i++ {
+				size += len(packed.At(i))
+			}
+			// the capacity is only rounded up to the size class of the allocation
+			if len(packed.data) != size || cap(packed.data) > size+size/8 {
+				t.Errorf("expected buffer of %d bytes without spare capacity, got length %d and capacity %d",
+					size, len(packed.data), cap(packed.data))
+			}
+		})
+	}
+
+	t.Run("limits capacity of returned ids", func(t *testing.T) {
+		generator, err := New(WithCount(10), WithLength(4), WithCharList(charsAlphanumeric), WithSeed(9))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		packed, err := generator.Packed(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected packed method error: %s", err)
+		}
+
+		second := string(packed.At(1))
+		_ = append(packed.At(0), 'X')
+		if string(packed.At(1)) != second {
+			t.Errorf("expected appending to an id not to overwrite the next one, got %s", packed.At(1))
+		}
+	})
+
+	t.Run("does not allocate memory per id", func(t *testing.T) {
+		idsToGenerate := 100_000
+		allocs := testing.AllocsPerRun(1, func() {
+			generator, err := New(WithCount(idsToGenerate), WithLength(12), WithCharList(charsAlphanumeric), WithSeed(9))
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			if _, err = generator.Packed(context.Background()); err != nil {
+				t.Fatalf("unexpected packed method error: %s", err)
+			}
+		})
+		if allocs > float64(idsToGenerate/100) {
+			t.Errorf("expected fewer than %d allocations, got %f", idsToGenerate/100, allocs)
+		}
+	})
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("returns partial result when memory limit is reached with %d workers", workers), func(t *testing.T) {
+			idLength, memoryLimit := 16, 1<<16
+			opts := []Option{
+				WithCount(20_000), WithLength(idLength), WithCharList(charsAlphanumeric), WithSeed(3), WithWorkers(workers),
+			}
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(append(opts, WithArrayMemoryLimit(memoryLimit))...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			packed, err := generator.Packed(context.Background())
+			var limitErr *MemoryLimitError
+			if !errors.As(err, &limitErr) {
+				t.Fatalf("expected memory limit error, got %v", err)
+			}
+
+			if cap(packed.data) > memoryLimit {
+				t.Errorf("expected buffer within memory limit of %d bytes, got capacity %d", memoryLimit, cap(packed.data))
+			}
+			if packed.Len() != memoryLimit/idLength {
+				t.Errorf("expected %d ids, got %d", memoryLimit/idLength, packed.Len())
+			}
+			assertSameIds(t, expected[:packed.Len()], packed.Slices())
+		})
+	}
+
+	t.Run("returns partial result for ids of different lengths when memory limit is reached", func(t *testing.T) {
+		memoryLimit := 20_000
+		opts := []Option{
+			WithCount(5000), WithLength(6), WithSymbols([]string{"a", "bb", "ccc", "δ", "€", "🙂"}), WithSeed(3),
+		}
+		expected := generateIdsWithOptions(t, opts...)
+
+		generator, err := New(append(opts, WithArrayMemoryLimit(memoryLimit))...)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		packed, err := generator.Packed(context.Background())
+		var limitErr *MemoryLimitError
+		if !errors.As(err, &limitErr) {
+			t.Fatalf("expected memory limit error, got %v", err)
+		}
+
+		if memoryUsed := cap(packed.data) + cap(packed.offsets)*offsetSize; memoryUsed > memoryLimit {
+			t.Errorf("expected at most %d bytes used, got %d", memoryLimit, memoryUsed)
+		}
+		// every id takes at most 24 bytes and an offset
+		if minIds := memoryLimit / (24 + offsetSize); packed.Len() < minIds {
+			t.Errorf("expected at least %d ids, got %d", minIds, packed.Len())
+		}
+		assertSameIds(t, expected[:packed.Len()], packed.Slices())
+	})
+}
+
+func TestGenerator_ArrayContiguous(t *testing.T) {
+	expected := generateIdsWithSeed(t, 1000, 8, charsAlphanumeric, 4)
+
+	generator, err := NewGeneratorWithSeed(1000, 8, charsAlphanumeric, 4)
+	if err != nil {
+		t.Fatalf("unexpected constructor error: %s", err)
+	}
+
+	results, err := generator.ArrayContiguous(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected array contiguous method error: %s", err)
+	}
+	assertSameIds(t, expected, results)
+
+	for i := 1; i < len(results); i++ {
+		previous := uintptr(unsafe.Pointer(unsafe.SliceData(results[i-1])))
+		if uintptr(unsafe.Pointer(unsafe.SliceData(results[i]))) != previous+8 {
+			t.Fatalf("expected id %d to follow the previous one in the buffer", i)
+		}
+	}
+
+	if _, err = generator.ArrayContiguous(context.Background()); !errors.Is(err, ErrUsed) {
+		t.Errorf("expected used error, got %v", err)
+	}
+}
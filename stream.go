@@ -0,0 +1,108 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrStreamClosed = errors.New("stream closed")
+
+// Stream is an alternative to the channel returned by the Channel method, which can be stopped at any time
+// by the consumer. Ids are delivered through an unbuffered channel, so that the number of ids received
+// by the consumer is known exactly, while they are still generated ahead into a buffer of the configured size.
+type Stream struct {
+	g      *Generator
+	ids    chan []byte
+	done   chan struct{}
+	cancel context.CancelCauseFunc
+
+	idsStart  int
+	delivered int
+}
+
+// Stream method starts generating the set of ids specified in the Generator constructor and returns a Stream
+// to retrieve them one by one. Like Array and Channel methods, it can be used only once per Generator.
+func (g *Generator) Stream(ctx context.Context) (*Stream, error) {
+	err := g.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	s := &Stream{
+		g:        g,
+		ids:      make(chan []byte),
+		done:     make(chan struct{}),
+		cancel:   cancel,
+		idsStart: g.idsGenerated,
+	}
+
+	idsChan := make(chan []byte, g.bufferSize)
+	go g.streamToChannel(ctx, idsChan)
+	go s.forward(ctx, idsChan)
+
+	return s, nil
+}
+
+// IDs returns the channel to receive the ids from. It is closed when all ids are delivered,
+// the context is cancelled or the Stream is closed.
+func (s *Stream) IDs() <-chan []byte {
+	return s.ids
+}
+
+// Done returns a channel which is closed once the Stream has stopped, after the channel of ids is closed.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close stops generating ids and waits until the Stream has stopped. Ids generated but not yet received
+// are discarded. If not all ids were delivered, the interruption error wraps ErrStreamClosed.
+func (s *Stream) Close() error {
+	s.cancel(ErrStreamClosed)
+	<-s.done
+
+	return nil
+}
+
+// Err returns nil until the Stream has stopped. Then it returns the same error as the InterruptionErr method
+// of the Generator: nil if all ids were delivered, or the reason of stopping otherwise, reported at the number
+// of ids actually received by the consumer. The progress available from the Checkpoint method
+// also counts the received ids only, so the discarded ones are generated again after resuming.
+func (s *Stream) Err() error {
+	select {
+	case <-s.done:
+		return s.g.InterruptionErr()
+	default:
+		return nil
+	}
+}
+
+func (s *Stream) forward(ctx context.Context, idsChan <-chan []byte) {
+	defer s.cancel(nil)
+	defer close(s.done)
+	defer close(s.ids)
+
+	for id := range idsChan {
+		if !send(ctx, s.ids, id) {
+			break
+		}
+		s.delivered++
+	}
+
+	// the producer stops promptly once the context is cancelled, and its remaining ids are discarded
+	for range idsChan {
+	}
+
+	idsDelivered := s.idsStart + s.delivered
+	if idsDelivered == s.g.shardEnd {
+		return
+	}
+
+	err := context.Cause(ctx)
+	if err == nil {
+		err = errors.Unwrap(s.g.InterruptionErr())
+	}
+
+	s.g.setIdsGenerated(idsDelivered)
+	s.g.setInterruptionErr(idsDelivered, err)
+}
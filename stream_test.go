@@ -0,0 +1,191 @@
+package generateids
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator_Stream(t *testing.T) {
+	t.Run("returns the same ids as array", func(t *testing.T) {
+		expected := generateIdsWithSeed(t, 1024, 16, charsAlphanumeric, 1)
+
+		generator, err := NewGeneratorWithSeed(1024, 16, charsAlphanumeric, 1)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		stream, err := generator.Stream(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected stream method error: %s", err)
+		}
+
+		var results [][]byte
+		for id := range stream.IDs() {
+			results = append(results, id)
+		}
+		<-stream.Done()
+
+		if stream.Err() != nil {
+			t.Errorf("expected no stream error, got %v", stream.Err())
+		}
+		assertSameIds(t, expected, results)
+	})
+
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("reports delivered ids when closed with %d workers", workers), func(t *testing.T) {
+			opts := []Option{WithCount(20_000), WithLength(8), WithCharList(charsAlphanumeric), WithSeed(2), WithWorkers(workers)}
+			expected := generateIdsWithOptions(t, opts...)
+
+			generator, err := New(opts...)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			stream, err := generator.Stream(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected stream method error: %s", err)
+			}
+
+			var results [][]byte
+			for id := range stream.IDs() {
+				if results = append(results, id); len(results) == 5000 {
+					break
+				}
+			}
+			assertStopped(t, stream)
+
+			if !errors.Is(stream.Err(), ErrStreamClosed) || !strings.Contains(stream.Err().Error(), " 5000:") {
+				t.Errorf("expected stream closed error at 5000, got %v", stream.Err())
+			}
+
+			checkpoint, err := generator.Checkpoint()
+			if err != nil {
+				t.Fatalf("unexpected checkpoint error: %s", err)
+			}
+
+			resumed, err := NewGeneratorFromCheckpoint(checkpoint)
+			if err != nil {
+				t.Fatalf("unexpected checkpoint constructor error: %s", err)
+			}
+
+			rest, err := resumed.Array(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected array method error: %s", err)
+			}
+			assertSameIds(t, expected, append(results, rest...))
+		})
+	}
+
+	t.Run("reports delivered ids when context is cancelled", func(t *testing.T) {
+		generator, err := New(WithCount(10_000), WithLength(8), WithCharList(charsAlphanumeric), WithBufferSize(1000))
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := generator.Stream(ctx)
+		if err != nil {
+			t.Fatalf("unexpected stream method error: %s", err)
+		}
+
+		idsCount := 0
+		for range stream.IDs() {
+			if idsCount++; idsCount == 10 {
+				cancel()
+				break
+			}
+		}
+
+		select {
+		case <-stream.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected stream to stop after the context is cancelled")
+		}
+
+		if !errors.Is(stream.Err(), context.Canceled) || !strings.Contains(stream.Err().Error(), " 10:") {
+			t.Errorf("expected context error at 10, got %v", stream.Err())
+		}
+	})
+
+	t.Run("returns no error when closed after all ids are delivered", func(t *testing.T) {
+		generator, err := NewGenerator(100, 4, charsAlphanumeric)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		stream, err := generator.Stream(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected stream method error: %s", err)
+		}
+
+		idsCount := 0
+		for range stream.IDs() {
+			idsCount++
+		}
+		assertStopped(t, stream)
+
+		if idsCount != 100 || stream.Err() != nil {
+			t.Errorf("expected 100 ids and no error, got %d and %v", idsCount, stream.Err())
+		}
+	})
+}
+
+func assertStopped(t *testing.T, stream *Stream) {
+	t.Helper()
+
+	closed := make(chan struct{})
+	go func() {
+		stream.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected stream to stop after it is closed")
+	}
+
+	if _, ok := <-stream.IDs(); ok {
+		t.Error("expected channel of ids to be closed")
+	}
+}
+
+func TestGenerator_ChannelAbandoned(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		t.Run(fmt.Sprintf("stops producer with %d workers", workers), func(t *testing.T) {
+			generator, err := New(WithCount(100_000), WithLength(8), WithCharList(charsAlphanumeric), WithWorkers(workers))
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			idsChan, err := generator.Channel(ctx)
+			if err != nil {
+				t.Fatalf("unexpected channel method error: %s", err)
+			}
+
+			<-idsChan
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+
+			// the channel is no longer read, so the producer must stop by itself
+			deadline := time.Now().Add(5 * time.Second)
+			for generator.InterruptionErr() == nil {
+				if time.Now().After(deadline) {
+					t.Fatal("expected producer to stop after the context is cancelled")
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			if !errors.Is(generator.InterruptionErr(), context.Canceled) {
+				t.Errorf("expected context error, got %v", generator.InterruptionErr())
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package generateids
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+var ErrInvalidUTF8 = errors.New("id is not valid UTF-8")
+
+// Format selects how ids are written by the WriteAll method.
+type Format int
+
+const (
+	// FormatLines writes every id in a separate line.
+	FormatLines Format = iota
+	// FormatCSV writes a CSV table with a header and two columns: index of the id in the set and the id itself.
+	FormatCSV
+	// FormatJSON writes a single JSON array of strings. Writing stops with ErrInvalidUTF8 at the first id
+	// which is not valid UTF-8, as JSON strings cannot hold arbitrary bytes.
+	FormatJSON
+	// FormatJSONLines writes every id as a JSON string in a separate line. Like FormatJSON,
+	// it stops with ErrInvalidUTF8 at the first id which is not valid UTF-8.
+	FormatJSONLines
+	// FormatNUL writes every id followed by a NUL byte, as expected by xargs -0.
+	FormatNUL
+)
+
+const writerBufferSize = 64 * 1024
+
+// WriteAll method generates the set of ids specified in the Generator constructor and writes them to w
+// in the given format. It returns the number of bytes written and, like the Array method, either the wrapped
+// context error or the first error returned by w. Writes to w are buffered.
+// The name WriteTo is not used, as the method does not implement io.WriterTo.
+func (g *Generator) WriteAll(ctx context.Context, w io.Writer, format Format) (int64, error) {
+	if format < FormatLines || format > FormatNUL {
+		return 0, fmt.Errorf("%w: unknown format %d", ErrValidation, format)
+	}
+
+	err := g.start(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	cw := &countingWriter{writer: w}
+	bw := bufio.NewWriterSize(cw, writerBufferSize)
+	fw := newFormatWriter(bw, format)
+
+	index := g.idsGenerated
+	err = fw.writeHeader()
+	if err == nil {
+		err = g.forEach(ctx, func(id []byte) error {
+			index++
+			return fw.writeId(index-1, id)
+		})
+	}
+	if err == nil {
+		err = fw.writeFooter()
+	}
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err == nil {
+		err = g.InterruptionErr()
+	}
+
+	return cw.written, err
+}
+
+// forEach passes the generated ids to fn, until all ids are generated, generating is interrupted
+// or fn returns an error. Concurrent generators use their workers, other generators generate ids
+// synchronously in the calling goroutine.
+func (g *Generator) forEach(ctx context.Context, fn func(id []byte) error) error {
+	if g.workers <= 1 {
+		seq := g.newSequence()
+		for id, ok := seq.next(ctx); ok; id, ok = seq.next(ctx) {
+			if err := fn(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	idsChan := make(chan []byte, g.bufferSize)
+	go g.streamToChannel(ctx, idsChan)
+
+	var err error
+	for id := range idsChan {
+		if err == nil {
+			err = fn(id)
+			if err != nil {
+				cancel()
+			}
+		}
+	}
+
+	return err
+}
+
+type countingWriter struct {
+	writer  io.Writer
+	written int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.writer.Write(p)
+	cw.written += int64(n)
+
+	return n, err
+}
+
+type formatWriter struct {
+	writer    *bufio.Writer
+	csvWriter *csv.Writer
+	format    Format
+	first     bool
+}
+
+func newFormatWriter(writer *bufio.Writer, format Format) *formatWriter {
+	fw := &formatWriter{
+		writer: writer,
+		format: format,
+		first:  true,
+	}
+
+	if format == FormatCSV {
+		fw.csvWriter = csv.NewWriter(writer)
+	}
+
+	return fw
+}
+
+func (fw *formatWriter) writeHeader() error {
+	switch fw.format {
+	case FormatCSV:
+		return fw.csvWriter.Write([]string{"index", "id"})
+	case FormatJSON:
+		return fw.writer.WriteByte('[')
+	}
+
+	return nil
+}
+
+func (fw *formatWriter) writeId(index int, id []byte) error {
+	switch fw.format {
+	case FormatCSV:
+		return fw.csvWriter.Write([]string{strconv.Itoa(index), string(id)})
+	case FormatJSON:
+		if !fw.first {
+			if err := fw.writer.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		fw.first = false
+
+		return fw.writeJSONString(id)
+	case FormatJSONLines:
+		if err := fw.writeJSONString(id); err != nil {
+			return err
+		}
+
+		return fw.writer.WriteByte('\n')
+	case FormatNUL:
+		if _, err := fw.writer.Write(id); err != nil {
+			return err
+		}
+
+		return fw.writer.WriteByte(0)
+	}
+
+	if _, err := fw.writer.Write(id); err != nil {
+		return err
+	}
+
+	return fw.writer.WriteByte('\n')
+}
+
+func (fw *formatWriter) writeJSONString(id []byte) error {
+	// json.Marshal would replace invalid bytes with U+FFFD, writing a different id
+	if !utf8.Valid(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidUTF8, id)
+	}
+
+	encoded, err := json.Marshal(string(id))
+	if err != nil {
+		return err
+	}
+
+	_, err = fw.writer.Write(encoded)
+	return err
+}
+
+func (fw *formatWriter) writeFooter() error {
+	switch fw.format {
+	case FormatCSV:
+		fw.csvWriter.Flush()
+		return fw.csvWriter.Error()
+	case FormatJSON:
+		_, err := fw.writer.WriteString("]\n")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package generateids
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_WriteAll(t *testing.T) {
+	seed := int64(0)
+	idsToGenerate := 1024
+	expected := generateIdsWithSeed(t, idsToGenerate, 16, charsAlphanumeric, seed)
+
+	testCases := []struct {
+		name   string
+		format Format
+		parse  func(t *testing.T, data []byte) [][]byte
+	}{
+		{"writes ids in separate lines", FormatLines, parseSeparated('\n')},
+		{"writes ids separated with NUL bytes", FormatNUL, parseSeparated(0)},
+		{"writes ids as csv with index column", FormatCSV, parseCSV},
+		{"writes ids as json array", FormatJSON, parseJSON},
+		{"writes ids as json lines", FormatJSONLines, parseJSONLines},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			generator, err := NewGeneratorWithSeed(idsToGenerate, 16, charsAlphanumeric, seed)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			buf := &bytes.Buffer{}
+			written, err := generator.WriteAll(context.Background(), buf, tc.format)
+			if err != nil {
+				t.Fatalf("unexpected write all method error: %s", err)
+			}
+
+			if written != int64(buf.Len()) {
+				t.Errorf("expected %d bytes written, got %d", buf.Len(), written)
+			}
+			assertSameIds(t, expected, tc.parse(t, buf.Bytes()))
+		})
+	}
+
+	t.Run("returns writer error", func(t *testing.T) {
+		generator, err := NewConcurrentGeneratorWithSeed(idsToGenerate, 16, charsAlphanumeric, seed, 2)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		writerErr := errors.New("disk full")
+		_, err = generator.WriteAll(context.Background(), failingWriter{writerErr}, FormatLines)
+		if !errors.Is(err, writerErr) {
+			t.Errorf("expected writer error, got %v", err)
+		}
+	})
+
+	t.Run("returns error when id is not valid UTF-8 in JSON", func(t *testing.T) {
+		for _, format := range []Format{FormatJSON, FormatJSONLines} {
+			generator, err := NewGeneratorWithSeed(4, 2, []byte{'a', 0xff}, seed)
+			if err != nil {
+				t.Fatalf("unexpected constructor error: %s", err)
+			}
+
+			_, err = generator.WriteAll(context.Background(), &bytes.Buffer{}, format)
+			if !errors.Is(err, ErrInvalidUTF8) {
+				t.Errorf("expected invalid UTF-8 error for format %d, got %v", format, err)
+			}
+		}
+	})
+
+	t.Run("returns error when format is unknown", func(t *testing.T) {
+		generator, err := NewGeneratorWithSeed(idsToGenerate, 16, charsAlphanumeric, seed)
+		if err != nil {
+			t.Fatalf("unexpected constructor error: %s", err)
+		}
+
+		_, err = generator.WriteAll(context.Background(), &bytes.Buffer{}, Format(-1))
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (fw failingWriter) Write([]byte) (int, error) {
+	return 0, fw.err
+}
+
+func parseSeparated(separator byte) func(t *testing.T, data []byte) [][]byte {
+	return func(t *testing.T, data []byte) [][]byte {
+		if len(data) == 0 || data[len(data)-1] != separator {
+			t.Fatalf("expected output to end with %q", separator)
+		}
+
+		return bytes.Split(data[:len(data)-1], []byte{separator})
+	}
+}
+
+func parseCSV(t *testing.T, data []byte) [][]byte {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected csv error: %s", err)
+	}
+
+	if len(records) == 0 || strings.Join(records[0], ",") != "index,id" {
+		t.Fatalf("expected csv header, got %v", records)
+	}
+
+	var ids [][]byte
+	for expectedIndex, record := range records[1:] {
+		if record[0] != strconv.Itoa(expectedIndex) {
+			t.Errorf("expected index %d, got %s", expectedIndex, record[0])
+		}
+		ids = append(ids, []byte(record[1]))
+	}
+
+	return ids
+}
+
+func parseJSON(t *testing.T, data []byte) [][]byte {
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		t.Fatalf("unexpected json error: %s", err)
+	}
+
+	var ids [][]byte
+	for _, str := range strs {
+		ids = append(ids, []byte(str))
+	}
+
+	return ids
+}
+
+func parseJSONLines(t *testing.T, data []byte) [][]byte {
+	var ids [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var str string
+		if err := json.Unmarshal(scanner.Bytes(), &str); err != nil {
+			t.Fatalf("unexpected json error: %s", err)
+		}
+		ids = append(ids, []byte(str))
+	}
+
+	return ids
+}